@@ -0,0 +1,107 @@
+package funda
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+)
+
+// Cache stores raw response bodies of successful requests, keyed by request
+// URL.
+type Cache interface {
+	Get(key string) ([]byte, bool)
+	Set(key string, data []byte)
+}
+
+// DirCache is a Cache that stores response bodies as files in a directory.
+type DirCache struct {
+	dir string
+}
+
+// NewDirCache returns a DirCache storing files in dir. The directory is
+// created on first write when it doesn't exist yet.
+func NewDirCache(dir string) *DirCache {
+	return &DirCache{dir: dir}
+}
+
+// Get returns the cached data for key, if any.
+func (c *DirCache) Get(key string) ([]byte, bool) {
+	data, err := os.ReadFile(c.path(key))
+	if err != nil {
+		return nil, false
+	}
+	return data, true
+}
+
+// Set stores data for key. Errors writing to disk are ignored, as the cache
+// is only an optimisation.
+func (c *DirCache) Set(key string, data []byte) {
+	if err := os.MkdirAll(c.dir, 0o755); err != nil {
+		return
+	}
+
+	// Write to a temporary file first, so readers never see partial data.
+	tmp, err := os.CreateTemp(c.dir, ".tmp-*")
+	if err != nil {
+		return
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return
+	}
+	if err := tmp.Close(); err != nil {
+		return
+	}
+
+	os.Rename(tmp.Name(), c.path(key))
+}
+
+func (c *DirCache) path(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return filepath.Join(c.dir, hex.EncodeToString(sum[:])+".json")
+}
+
+// cachedResponse returns a response for req from the cache, if any.
+func (c *Client) cachedResponse(req *http.Request) (*http.Response, bool) {
+	data, ok := c.Cache.Get(req.URL.String())
+	if !ok {
+		return nil, false
+	}
+
+	return &http.Response{
+		Status:        "200 OK",
+		StatusCode:    http.StatusOK,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        make(http.Header),
+		Body:          io.NopCloser(bytes.NewReader(data)),
+		ContentLength: int64(len(data)),
+		Request:       req,
+	}, true
+}
+
+// cacheResponse stores the body of a successful response in the cache. The
+// body of resp is replaced, so it can still be read by the caller.
+func (c *Client) cacheResponse(req *http.Request, resp *http.Response) error {
+	if resp.StatusCode != http.StatusOK {
+		return nil
+	}
+
+	data, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return err
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(data))
+
+	c.Cache.Set(req.URL.String(), data)
+
+	return nil
+}
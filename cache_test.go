@@ -0,0 +1,40 @@
+package funda
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestSearchDirCache(t *testing.T) {
+	var reqs int
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		reqs++
+		if r.URL.Path == "/Aanbod/koop" {
+			http.ServeFile(w, r, "test_data/funda_search_response.json")
+			return
+		}
+		http.ServeFile(w, r, "test_data/funda_house_response.json")
+	}))
+	defer ts.Close()
+
+	fundaClient := NewClient("foobar")
+	fundaClient.BaseURL = ts.URL
+	fundaClient.Cache = NewDirCache(t.TempDir())
+
+	for i := 0; i < 2; i++ {
+		got, err := fundaClient.Search("", 1, 25)
+		if err != nil {
+			t.Fatalf("Got: %v, expected %v", err, nil)
+		}
+		if len(got) != 1 || got[0].PriceEUR != 400000 {
+			t.Fatalf("Got: %v houses, expected populated house", len(got))
+		}
+	}
+
+	// The second search is served from the cache entirely.
+	if reqs != 2 {
+		t.Fatalf("Got: %v requests, expected %v", reqs, 2)
+	}
+}
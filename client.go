@@ -1,18 +1,54 @@
 package funda
 
 import (
+	"compress/gzip"
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
-	"log"
+	"math/rand"
 	"net/http"
 	"net/url"
 	"strconv"
+	"strings"
+	"sync"
+	"time"
 )
 
 const baseURL = "https://mobile.funda.io/api/v1"
 
+// defaultUserAgent mimics the official Funda Android app.
+const defaultUserAgent = "Funda/2.17.0 (com.funda.two; build:80; Android 25) okhttp/3.5.0"
+
+// defaultAcceptLanguage requests listings for the Dutch site.
+const defaultAcceptLanguage = "nl-NL"
+
+// defaultDetailConcurrency is the number of house detail requests done
+// simultaneously when not configured on the Client.
+const defaultDetailConcurrency = 4
+
+// defaultRetryBackoff is the initial time to wait before retrying a request
+// when not configured on the Client.
+const defaultRetryBackoff = 500 * time.Millisecond
+
+// maxRetryBackoff is the longest time to wait before retrying a request, not
+// counting jitter.
+const maxRetryBackoff = 30 * time.Second
+
+// defaultTimeout is the timeout of requests done with the default HTTP client.
+const defaultTimeout = 30 * time.Second
+
+// defaultMaxResponseBytes is the maximum size of response bodies when not
+// configured on the Client.
+const defaultMaxResponseBytes = 10 << 20
+
+// maxSearchPages guards SearchAll against requesting pages indefinitely.
+const maxSearchPages = 100
+
+// maxSearchNPageSize is the largest page size SearchN requests.
+const maxSearchNPageSize = 25
+
 type searchResultItem struct {
 	ItemType int    `json:"ItemType"`
 	GlobalID int    `json:"GlobalId"`
@@ -32,54 +68,458 @@ type foto struct {
 type searchResult []searchResultItem
 
 type houseResponseItem struct {
-	URL     string            `json:"URL"`
-	List    []json.RawMessage `json:"List"`
-	Section int               `json:"Section"`
+	URL         string            `json:"URL"`
+	List        []json.RawMessage `json:"List"`
+	Section     int               `json:"Section"`
+	Latitude    float64           `json:"Latitude"`
+	Longitude   float64           `json:"Longitude"`
+	Makelaars   []makelaar        `json:"Makelaars"`
+	Description string            `json:"Description"`
+	Subject     string            `json:"Subject"`
+}
+
+type makelaar struct {
+	Name    string   `json:"Name"`
+	Link    string   `json:"Link"`
+	Buttons []button `json:"Buttons"`
+}
+
+type button struct {
+	Type   int    `json:"Type"`
+	Action string `json:"Action"`
+	Text   string `json:"Text"`
 }
 
 type houseResponseItemList struct {
-	Label string            `json:"Label"`
-	Value string            `json:"Value"`
-	Text  string            `json:"Text"`
-	List  []json.RawMessage `json:"List"`
+	Title        string                  `json:"Title"`
+	Label        string                  `json:"Label"`
+	Value        string                  `json:"Value"`
+	Text         string                  `json:"Text"`
+	List         []json.RawMessage       `json:"List"`
+	Line         []houseResponseItemList `json:"Line"`
+	EnergieLabel info                    `json:"EnergieLabel"`
+	Css          string                  `json:"Css"`
 }
 
 type houseResponse []houseResponseItem
 
 // Client defines an HTTP client to the Funda API.
 type Client struct {
+	// HTTPClient is used for all requests. By default it times out requests
+	// after 30 seconds; a custom HTTPClient replaces this timeout with its
+	// own.
 	HTTPClient *http.Client
 	BaseURL    string
 	APIKey     string
+
+	// UserAgent is sent as the User-Agent header of every request.
+	UserAgent string
+
+	// AcceptLanguage is sent as the Accept-Language header of every request.
+	AcceptLanguage string
+
+	// SearchPathPrefix, when set, replaces the search endpoint path relative
+	// to BaseURL, which otherwise depends on the listing type (e.g.
+	// "/Aanbod/koop").
+	SearchPathPrefix string
+
+	// ExtraHeaders are added to every request, replacing any default values
+	// for the same keys. E.g., a "Cookie" key replaces the default cookie.
+	ExtraHeaders http.Header
+
+	// CookieJar, when set, stores cookies of responses, and adds them to
+	// subsequent requests, e.g. for session cookies. It is used as the Jar of
+	// a copy of the HTTPClient, so it also applies to redirects, and replaces
+	// any Jar of the HTTPClient.
+	CookieJar http.CookieJar
+
+	// OnRequest and OnResponse, when set, are called for every request sent
+	// and every response received, including retries, e.g. for debugging.
+	// Responses served from Cache are not sent, and skip both.
+	OnRequest  func(*http.Request)
+	OnResponse func(*http.Response)
+
+	// MaxResponseBytes is the maximum size of a response body. Reading beyond
+	// it fails with ErrResponseTooLarge.
+	MaxResponseBytes int64
+
+	// DetailConcurrency is the maximum number of house detail requests done
+	// simultaneously for a search.
+	DetailConcurrency int
+
+	// Logger logs non-fatal errors. By default nothing is logged.
+	Logger Logger
+
+	// Metrics observes every HTTP request. By default nothing is recorded.
+	Metrics Metrics
+
+	// Now returns the current time, which relative dates in house details
+	// (e.g. "3 weken") are parsed against. It defaults to time.Now.
+	Now func() time.Time
+
+	// MaxRetries is the number of times a request is retried after a network
+	// error or 5xx response. Retries are done with exponential backoff,
+	// starting at RetryBackoff.
+	MaxRetries   int
+	RetryBackoff time.Duration
+
+	// RateLimiter, when set, is waited on before every outgoing request.
+	RateLimiter RateLimiter
+
+	// Cache, when set, is consulted before every request, and stores the
+	// bodies of successful responses.
+	Cache Cache
+
+	// IncludeAds makes searches include highlighted (sponsored) listings,
+	// which have IsAd set.
+	IncludeAds bool
+
+	// ExcludeSold makes searches omit listings shown as sold or under bid in
+	// the search results, without requesting their details.
+	ExcludeSold bool
+
+	// SkipDetails makes searches return houses populated only from the search
+	// results, without requesting the details of every house.
+	SkipDetails bool
+
+	// StrictParsing makes a search fail on the first search result that
+	// lacks photos or info values, instead of skipping that result.
+	StrictParsing bool
+
+	// DetailPathFunc, when set, returns the path of the detail endpoint of a
+	// house relative to BaseURL (e.g. "/Aanbod/Detail/Koop/4094475"). By
+	// default the path depends on the listing type.
+	DetailPathFunc func(globalID int) string
+
+	// SortOrder is the order in which searches return listings. By default
+	// Funda's own ordering is used.
+	SortOrder SortOrder
+}
+
+// RateLimiter limits the rate of outgoing requests. It is satisfied by
+// *rate.Limiter from golang.org/x/time/rate.
+type RateLimiter interface {
+	Wait(ctx context.Context) error
+}
+
+// ListingType defines the kind of listings to search for.
+type ListingType int
+
+// Listing types supported by the Funda API.
+const (
+	ListingBuy ListingType = iota
+	ListingRent
+)
+
+// SortOrder defines the order of search results.
+type SortOrder int
+
+// Sort orders supported by the Funda API.
+const (
+	SortOrderDefault SortOrder = iota
+	SortOrderNewest
+	SortOrderPriceAsc
+	SortOrderPriceDesc
+)
+
+// queryValue returns the value of the "sort" query parameter for the sort
+// order, or an empty string for the default order.
+func (o SortOrder) queryValue() string {
+	switch o {
+	case SortOrderNewest:
+		return "date_down"
+	case SortOrderPriceAsc:
+		return "price_up"
+	case SortOrderPriceDesc:
+		return "price_down"
+	default:
+		return ""
+	}
+}
+
+// searchPath returns the path segment of the search endpoint for the listing
+// type.
+func (t ListingType) searchPath() string {
+	if t == ListingRent {
+		return "huur"
+	}
+	return "koop"
+}
+
+// detailPath returns the path segment of the detail endpoint for the listing
+// type.
+func (t ListingType) detailPath() string {
+	if t == ListingRent {
+		return "Huur"
+	}
+	return "Koop"
 }
 
 // NewClient initialises and returns a new Client.
 func NewClient(apiKey string) *Client {
-	return &Client{
-		HTTPClient: http.DefaultClient,
-		BaseURL:    baseURL,
-		APIKey:     apiKey,
+	return NewClientWithOptions(apiKey)
+}
+
+// NewClientWithOptions initialises and returns a new Client, configured with
+// opts.
+func NewClientWithOptions(apiKey string, opts ...Option) *Client {
+	c := &Client{
+		HTTPClient:        &http.Client{Timeout: defaultTimeout},
+		BaseURL:           baseURL,
+		APIKey:            apiKey,
+		UserAgent:         defaultUserAgent,
+		AcceptLanguage:    defaultAcceptLanguage,
+		DetailConcurrency: defaultDetailConcurrency,
+		MaxResponseBytes:  defaultMaxResponseBytes,
+		Logger:            nopLogger{},
+		Metrics:           nopMetrics{},
+		Now:               time.Now,
+	}
+
+	for _, opt := range opts {
+		opt(c)
+	}
+
+	return c
+}
+
+// Validate returns an error if the client is not configured correctly for
+// doing requests.
+func (c *Client) Validate() error {
+	if c.APIKey == "" {
+		return ErrMissingAPIKey
 	}
+	if c.BaseURL == "" {
+		return errors.New("funda: missing base URL")
+	}
+
+	return nil
+}
+
+// Clone returns a copy of c that can be changed without affecting c, e.g. to
+// use different headers for some requests. ExtraHeaders is copied; the
+// HTTPClient, Logger, RateLimiter, Cache and other values shared by pointer or
+// interface remain shared, unless replaced on the copy.
+func (c *Client) Clone() *Client {
+	clone := *c
+	clone.ExtraHeaders = c.ExtraHeaders.Clone()
+
+	return &clone
 }
 
-func (c *Client) newRequest(method, url string, body io.Reader) (*http.Request, error) {
-	req, err := http.NewRequest(method, url, body)
+func (c *Client) newRequest(ctx context.Context, method, url string, body io.Reader) (*http.Request, error) {
+	req, err := http.NewRequestWithContext(ctx, method, url, body)
 	if err != nil {
 		return nil, err
 	}
 
 	req.Header.Set("accepted_cookie_policy", "10")
 	req.Header.Set("api_key", c.APIKey)
-	req.Header.Set("User-Agent", "Funda/2.17.0 (com.funda.two; build:80; Android 25) okhttp/3.5.0")
+	req.Header.Set("User-Agent", c.UserAgent)
 	req.Header.Set("Cookie", "X-Stored-Data=null; expires=Fri, 31 Dec 9999 23:59:59 GMT; path=/; samesite=lax; httponly")
 	req.Header.Set("Accept", "application/json")
-	req.Header.Set("Accept-Language", "nl-NL")
+	req.Header.Set("Accept-Encoding", "gzip")
+	req.Header.Set("Accept-Language", c.AcceptLanguage)
+
+	if c.UserAgent == "" {
+		req.Header.Set("User-Agent", defaultUserAgent)
+	}
+	if c.AcceptLanguage == "" {
+		req.Header.Set("Accept-Language", defaultAcceptLanguage)
+	}
+
+	for key, values := range c.ExtraHeaders {
+		req.Header.Del(key)
+		for _, v := range values {
+			req.Header.Add(key, v)
+		}
+	}
 
 	return req, nil
 }
 
-func (c *Client) fundaSearchURL(searchOpts string, page, pageSize int) (*url.URL, error) {
-	u, err := url.Parse(c.BaseURL + "/Aanbod/koop" + searchOpts)
+// do executes the request, retrying network errors and 5xx responses up to
+// MaxRetries times. Responses are served from and stored in Cache, if set.
+// Waiting between attempts is aborted when the request's context is done.
+// Requests are observed by Metrics under route.
+func (c *Client) do(req *http.Request, route string) (*http.Response, error) {
+	ctx := req.Context()
+
+	if c.Cache != nil {
+		if resp, ok := c.cachedResponse(req); ok {
+			return resp, nil
+		}
+	}
+
+	httpClient := c.httpClient()
+
+	for attempt := 0; ; attempt++ {
+		if c.RateLimiter != nil {
+			if err := c.RateLimiter.Wait(ctx); err != nil {
+				return nil, err
+			}
+		}
+
+		if c.OnRequest != nil {
+			c.OnRequest(req)
+		}
+		start := time.Now()
+		resp, err := httpClient.Do(req)
+		status := 0
+		if err == nil {
+			status = resp.StatusCode
+		}
+		c.metrics().ObserveRequest(route, status, time.Since(start))
+
+		if err == nil && c.OnResponse != nil {
+			c.OnResponse(resp)
+		}
+		if err == nil && resp.StatusCode < 500 {
+			if err := decompressBody(resp); err != nil {
+				resp.Body.Close()
+				return nil, err
+			}
+			resp.Body = &limitedBody{ReadCloser: resp.Body, remaining: c.maxResponseBytes()}
+			if c.Cache != nil {
+				if err := c.cacheResponse(req, resp); err != nil {
+					return nil, err
+				}
+			}
+			return resp, nil
+		}
+		if attempt >= c.MaxRetries || ctx.Err() != nil {
+			return resp, err
+		}
+		if resp != nil {
+			resp.Body.Close()
+		}
+
+		timer := time.NewTimer(c.retryBackoff(attempt))
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
+
+// decompressBody replaces the body of a gzip-encoded response with its
+// decompressed contents. As Accept-Encoding is set explicitly, the transport
+// doesn't do this itself.
+func decompressBody(resp *http.Response) error {
+	if !strings.EqualFold(resp.Header.Get("Content-Encoding"), "gzip") {
+		return nil
+	}
+
+	zr, err := gzip.NewReader(resp.Body)
+	if err != nil {
+		return fmt.Errorf("could not decompress response body: %w", err)
+	}
+
+	resp.Body = gzipReadCloser{Reader: zr, body: resp.Body}
+	resp.Header.Del("Content-Encoding")
+	resp.Header.Del("Content-Length")
+	resp.ContentLength = -1
+
+	return nil
+}
+
+// gzipReadCloser reads a decompressed response body, and closes both the
+// gzip reader and the underlying body.
+type gzipReadCloser struct {
+	*gzip.Reader
+	body io.ReadCloser
+}
+
+func (r gzipReadCloser) Close() error {
+	r.Reader.Close()
+	return r.body.Close()
+}
+
+// maxResponseBytes returns the configured maximum size of response bodies,
+// or the default if none is set.
+func (c *Client) maxResponseBytes() int64 {
+	if c.MaxResponseBytes < 1 {
+		return defaultMaxResponseBytes
+	}
+	return c.MaxResponseBytes
+}
+
+// httpClient returns the HTTPClient, or a copy of it using CookieJar as its
+// Jar if set.
+func (c *Client) httpClient() *http.Client {
+	if c.CookieJar == nil || c.HTTPClient.Jar == c.CookieJar {
+		return c.HTTPClient
+	}
+
+	httpClient := *c.HTTPClient
+	httpClient.Jar = c.CookieJar
+	return &httpClient
+}
+
+// limitedBody reads a response body, failing with ErrResponseTooLarge once
+// more than remaining bytes are read.
+type limitedBody struct {
+	io.ReadCloser
+	remaining int64
+}
+
+func (b *limitedBody) Read(p []byte) (int, error) {
+	if b.remaining < 0 {
+		return 0, ErrResponseTooLarge
+	}
+
+	// Read one byte beyond the limit, to tell whether the body exceeds it.
+	// Compare against len(p)-1, as remaining+1 overflows for math.MaxInt64.
+	if int64(len(p))-1 > b.remaining {
+		p = p[:b.remaining+1]
+	}
+
+	n, err := b.ReadCloser.Read(p)
+	b.remaining -= int64(n)
+	if b.remaining < 0 {
+		return n + int(b.remaining), ErrResponseTooLarge
+	}
+
+	return n, err
+}
+
+// now returns the current time of the configured clock, or of time.Now if
+// none is set.
+func (c *Client) now() time.Time {
+	if c.Now == nil {
+		return time.Now()
+	}
+	return c.Now()
+}
+
+// retryBackoff returns the time to wait before retrying after the given
+// (zero-based) attempt: RetryBackoff doubled for every attempt, capped at
+// maxRetryBackoff, plus up to 50% random jitter.
+func (c *Client) retryBackoff(attempt int) time.Duration {
+	backoff := c.RetryBackoff
+	if backoff <= 0 {
+		backoff = defaultRetryBackoff
+	}
+	// Double step by step, as shifting by attempt at once can overflow.
+	for i := 0; i < attempt && backoff < maxRetryBackoff; i++ {
+		backoff <<= 1
+	}
+	if backoff > maxRetryBackoff {
+		backoff = maxRetryBackoff
+	}
+
+	return backoff + time.Duration(rand.Int63n(int64(backoff)/2+1))
+}
+
+func (c *Client) fundaSearchURL(listingType ListingType, searchOpts string, page, pageSize int) (*url.URL, error) {
+	prefix := c.SearchPathPrefix
+	if prefix == "" {
+		prefix = "/Aanbod/" + listingType.searchPath()
+	}
+
+	u, err := url.Parse(c.BaseURL + prefix + searchOpts)
 	if err != nil {
 		return nil, err
 	}
@@ -87,6 +527,9 @@ func (c *Client) fundaSearchURL(searchOpts string, page, pageSize int) (*url.URL
 	q := url.Values{}
 	q.Set("page", strconv.Itoa(page))
 	q.Set("pageSize", strconv.Itoa(pageSize))
+	if sort := c.SortOrder.queryValue(); sort != "" {
+		q.Set("sort", sort)
+	}
 
 	u.RawQuery = q.Encode()
 
@@ -95,82 +538,300 @@ func (c *Client) fundaSearchURL(searchOpts string, page, pageSize int) (*url.URL
 
 // Search does a house search request at the Funda API.
 func (c *Client) Search(searchOpts string, page, pageSize int) ([]*House, error) {
-	req, err := c.newRequest("GET", "", nil)
+	return c.SearchContext(context.Background(), searchOpts, page, pageSize)
+}
+
+// SearchContext does a house search request at the Funda API. The context is
+// used for the search request and for every house detail request it spawns.
+func (c *Client) SearchContext(ctx context.Context, searchOpts string, page, pageSize int) ([]*House, error) {
+	return c.SearchListingsContext(ctx, ListingBuy, searchOpts, page, pageSize)
+}
+
+// SearchWithQuery does a house search request at the Funda API using the
+// filters defined by q. An invalid query returns an error without doing any
+// requests.
+func (c *Client) SearchWithQuery(q SearchQuery, page, pageSize int) ([]*House, error) {
+	if err := q.Validate(); err != nil {
+		return nil, err
+	}
+
+	return c.Search(q.String(), page, pageSize)
+}
+
+// SearchMunicipalities does SearchAll for each of munis, using the other
+// filters of q, and merges the results with MergeHouses. Municipalities are
+// searched one after another, so rate limiting and detail concurrency apply
+// as for a single search. Errors for municipalities are joined, and returned
+// together with the houses of the other municipalities.
+func (c *Client) SearchMunicipalities(munis []string, q SearchQuery, pageSize int) ([]*House, error) {
+	if err := q.Validate(); err != nil {
+		return nil, err
+	}
+
+	var lists [][]*House
+	var errs []error
+
+	for _, muni := range munis {
+		mq := q
+		mq.Municipalities = []string{muni}
+
+		houses, err := c.SearchAll(mq.String(), pageSize)
+		if err != nil {
+			// The error already has the "funda:" prefix, so annotate it with
+			// the municipality at the end.
+			errs = append(errs, fmt.Errorf("%w (searching %v)", err, muni))
+			continue
+		}
+		lists = append(lists, houses)
+	}
+
+	return MergeHouses(lists...), errors.Join(errs...)
+}
+
+// SearchListings does a search request at the Funda API for listings of the
+// given type, e.g. rentals.
+func (c *Client) SearchListings(listingType ListingType, searchOpts string, page, pageSize int) ([]*House, error) {
+	return c.SearchListingsContext(context.Background(), listingType, searchOpts, page, pageSize)
+}
+
+// SearchListingsContext is like SearchListings, with a context used for the
+// search request and every house detail request it spawns.
+func (c *Client) SearchListingsContext(ctx context.Context, listingType ListingType, searchOpts string, page, pageSize int) ([]*House, error) {
+	result, err := c.searchPage(ctx, listingType, searchOpts, page, pageSize)
 	if err != nil {
-		return nil, fmt.Errorf("funda: could not create http request: %e", err)
+		return nil, err
 	}
 
-	u, err := c.fundaSearchURL(searchOpts, page, pageSize)
+	return result.Houses, nil
+}
+
+func (c *Client) searchPage(ctx context.Context, listingType ListingType, searchOpts string, page, pageSize int) (*SearchResult, error) {
+	u, err := c.fundaSearchURL(listingType, searchOpts, page, pageSize)
 	if err != nil {
-		return nil, fmt.Errorf("funda: could not parse search URL: %v", err)
+		return nil, fmt.Errorf("funda: could not parse search URL: %w", err)
+	}
+
+	return c.searchURL(ctx, listingType, u, page, pageSize)
+}
+
+// searchURL does a search request for u, and returns the houses parsed from
+// the response.
+func (c *Client) searchURL(ctx context.Context, listingType ListingType, u *url.URL, page, pageSize int) (*SearchResult, error) {
+	if err := c.Validate(); err != nil {
+		return nil, err
+	}
+
+	req, err := c.newRequest(ctx, "GET", "", nil)
+	if err != nil {
+		return nil, fmt.Errorf("funda: could not create http request: %w", err)
 	}
 	req.URL = u
 
-	resp, err := c.HTTPClient.Do(req)
+	resp, err := c.do(req, RouteSearch)
+	if ctx.Err() != nil {
+		return nil, fmt.Errorf("funda: %w", ctx.Err())
+	}
 	if err != nil {
-		return nil, fmt.Errorf("funda: could not execute http request: %v", err)
+		return nil, fmt.Errorf("funda: could not execute http request: %w", err)
 	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("funda: %w", &StatusError{StatusCode: resp.StatusCode})
+	}
+
+	body, err := decodeSearchResponse(resp.Body)
+	if err != nil {
 		return nil, fmt.Errorf(
-			"funda: unexpected HTTP response code (%d) received",
-			resp.StatusCode,
+			"funda: could not parse houses from search result: %w",
+			err,
 		)
 	}
 
-	houses, err := c.housesFromSearchResult(resp.Body)
+	houses, err := c.housesFromSearchResult(ctx, listingType, body.Objects)
+	if ctx.Err() != nil {
+		return nil, fmt.Errorf("funda: %w", ctx.Err())
+	}
 	if err != nil {
 		return nil, fmt.Errorf(
-			"funda: could not parse houses from search result: %v",
+			"funda: could not parse houses from search result: %w",
 			err,
 		)
 	}
 
-	return houses, nil
+	return body.searchResult(houses, page, pageSize), nil
 }
 
-func (c *Client) housesFromSearchResult(r io.Reader) ([]*House, error) {
-	var result searchResult
-	if err := json.NewDecoder(r).Decode(&result); err != nil {
+// SearchAll does house search requests at the Funda API for successive pages,
+// starting at page 1, until a page without results is returned. Houses listed
+// on multiple pages are only included once.
+func (c *Client) SearchAll(searchOpts string, pageSize int) ([]*House, error) {
+	var houses []*House
+
+	err := c.SearchAllFunc(context.Background(), searchOpts, pageSize, func(house *House) error {
+		houses = append(houses, house)
+		return nil
+	})
+	if err != nil {
 		return nil, err
 	}
 
+	return houses, nil
+}
+
+// SearchAllFunc is like SearchAll, but calls fn for every house instead of
+// returning all houses at once. Pages are only requested once fn has been
+// called for all houses of the previous page. If fn returns an error, the
+// search stops and that error is returned.
+func (c *Client) SearchAllFunc(ctx context.Context, searchOpts string, pageSize int, fn func(*House) error) error {
+	return c.searchAllFunc(ctx, searchOpts, pageSize, 0, fn)
+}
+
+// SearchN is like SearchAll, but stops once n houses have been collected,
+// even halfway a page. Details are only fetched for the houses returned. An n
+// of 0 means unlimited.
+func (c *Client) SearchN(searchOpts string, n int) ([]*House, error) {
+	pageSize := maxSearchNPageSize
+	if n > 0 && n < pageSize {
+		pageSize = n
+	}
+
 	var houses []*House
 
-	for _, item := range result {
-		// Skip highlighted houses (ads).
-		if item.ItemType != 1 {
-			continue
+	err := c.searchAllFunc(context.Background(), searchOpts, pageSize, n, func(house *House) error {
+		houses = append(houses, house)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return houses, nil
+}
+
+// searchAllFunc calls fn for every house of successive search pages, until a
+// page without results is returned, or limit houses have been found. A limit
+// of 0 means unlimited.
+func (c *Client) searchAllFunc(ctx context.Context, searchOpts string, pageSize, limit int, fn func(*House) error) error {
+	// Details are fetched separately, only for the houses within the limit.
+	sc := *c
+	sc.SkipDetails = true
+
+	seen := make(map[int]bool)
+	found := 0
+
+	for page := 1; page <= maxSearchPages; page++ {
+		result, err := sc.searchPage(ctx, ListingBuy, searchOpts, page, pageSize)
+		if err != nil {
+			return err
 		}
 
-		if len(item.Fotos) < 1 {
-			return nil, errors.New("result does not have photos")
+		// Stop on a page without listings, rather than on one of which all
+		// houses were skipped.
+		if result.listings == 0 {
+			break
 		}
 
-		if len(item.Info) < 4 {
-			return nil, errors.New("result does not have enough info values")
+		var unseen []*House
+		for _, house := range result.Houses {
+			if seen[house.ID] {
+				continue
+			}
+			seen[house.ID] = true
+			unseen = append(unseen, house)
 		}
 
-		for _, info := range item.Info {
-			if len(info.Line) < 1 {
-				return nil, errors.New("result does not have enough info lines")
+		// Fetch details for as many houses as still needed, and retry with the
+		// leftovers of the page for those that failed, so houses are returned
+		// in order.
+		for len(unseen) > 0 && (limit == 0 || found < limit) {
+			batch := unseen
+			if limit > 0 && len(batch) > limit-found {
+				batch = batch[:limit-found]
+			}
+			unseen = unseen[len(batch):]
+
+			if !c.SkipDetails {
+				batch, err = c.populateAllHouseDetails(ctx, batch)
+				if err != nil {
+					return fmt.Errorf("funda: %w", err)
+				}
+			}
+
+			for _, house := range batch {
+				if err := fn(house); err != nil {
+					return err
+				}
+				found++
 			}
 		}
 
-		house := &House{
-			ID:      item.GlobalID,
-			Address: item.Info[0].Line[0].Text,
+		if limit > 0 && found >= limit {
+			break
 		}
+	}
 
-		imageURL, err := url.Parse(item.Fotos[0].Link)
-		if err != nil {
-			return nil, err
+	return nil
+}
+
+func (c *Client) housesFromSearchResult(ctx context.Context, listingType ListingType, result searchResult) ([]*House, error) {
+	houses, err := c.parseSearchResult(listingType, result)
+	if err != nil {
+		return nil, err
+	}
+
+	if c.SkipDetails {
+		return houses, nil
+	}
+
+	return c.populateAllHouseDetails(ctx, houses)
+}
+
+// parseSearchResult returns the houses of a search result, populated only
+// from the search result items.
+func (c *Client) parseSearchResult(listingType ListingType, result searchResult) ([]*House, error) {
+	var houses []*House
+
+	for _, item := range result {
+		// Skip highlighted houses (ads), unless requested.
+		isAd := item.ItemType != 1
+		if isAd && !c.IncludeAds {
+			continue
 		}
-		house.ImageURL = *imageURL
 
-		if err := c.populateHouseDetails(house, item.GlobalID); err != nil {
-			log.Printf("Error: Could not get house (%v): %v", item.GlobalID, err)
+		if err := c.validateSearchResultItem(item); err != nil {
+			if c.StrictParsing {
+				return nil, err
+			}
+			c.logf("Error: Skipping house (%v): %v", item.GlobalID, err)
+			continue
+		}
+
+		house := newHouse(item.GlobalID)
+		house.ListingType = listingType
+		house.Address = item.Info[0].Line[0].Text
+		house.Title = strings.TrimSpace(house.Address)
+		house.IsAd = isAd
+
+		for _, foto := range item.Fotos {
+			imageURL, err := url.Parse(foto.Link)
+			if err != nil {
+				if c.StrictParsing {
+					return nil, err
+				}
+				// Photos are optional, so only skip the invalid one.
+				c.logf("Error: Skipping photo of house (%v): %v", item.GlobalID, err)
+				continue
+			}
+			house.ImageURLs = append(house.ImageURLs, *imageURL)
+		}
+		if len(house.ImageURLs) > 0 {
+			house.ImageURL = house.ImageURLs[0]
+		}
+
+		house.parseSearchInfo(item.Info)
+
+		if c.ExcludeSold && house.Status != StatusAvailable {
 			continue
 		}
 
@@ -180,29 +841,211 @@ func (c *Client) housesFromSearchResult(r io.Reader) ([]*House, error) {
 	return houses, nil
 }
 
-func (c *Client) populateHouseDetails(house *House, globalID int) error {
-	url := fmt.Sprintf("%v/Aanbod/Detail/Koop/%v", c.BaseURL, globalID)
-	req, err := c.newRequest("GET", url, nil)
+// validateSearchResultItem returns an error if the item lacks the values
+// needed to create a house. Photos are only required with StrictParsing.
+func (c *Client) validateSearchResultItem(item searchResultItem) error {
+	if c.StrictParsing && len(item.Fotos) < 1 {
+		return errors.New("result does not have photos")
+	}
+
+	if len(item.Info) < 4 {
+		return errors.New("result does not have enough info values")
+	}
+
+	for _, info := range item.Info {
+		if len(info.Line) < 1 {
+			return errors.New("result does not have enough info lines")
+		}
+	}
+
+	return nil
+}
+
+// populateAllHouseDetails fetches details for houses concurrently, using at
+// most DetailConcurrency simultaneous requests. Houses for which details could
+// not be fetched are logged and omitted; the order of houses is preserved.
+func (c *Client) populateAllHouseDetails(ctx context.Context, houses []*House) ([]*House, error) {
+	errs := c.fetchAllHouseDetails(ctx, houses)
+
+	// Stop once the context is done, rather than logging every aborted fetch.
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+
+	var populated []*House
+	for i, house := range houses {
+		if errs[i] != nil {
+			c.logf("Error: Could not get house (%v): %v", house.ID, errs[i])
+			continue
+		}
+		populated = append(populated, house)
+	}
+
+	return populated, nil
+}
+
+// fetchAllHouseDetails fetches details for houses concurrently, using at most
+// DetailConcurrency simultaneous requests, and returns the error for every
+// house.
+func (c *Client) fetchAllHouseDetails(ctx context.Context, houses []*House) []error {
+	concurrency := c.DetailConcurrency
+	if concurrency < 1 {
+		concurrency = defaultDetailConcurrency
+	}
+
+	jobs := make(chan int, len(houses))
+	for i := range houses {
+		jobs <- i
+	}
+	close(jobs)
+
+	errs := make([]error, len(houses))
+
+	var wg sync.WaitGroup
+	for w := 0; w < concurrency && w < len(houses); w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				// Don't start new fetches once the context is done.
+				if ctx.Err() != nil {
+					errs[i] = ctx.Err()
+					continue
+				}
+				errs[i] = c.populateHouseDetails(ctx, houses[i], houses[i].ID)
+			}
+		}()
+	}
+	wg.Wait()
+
+	return errs
+}
+
+// GetHouse fetches the details of a single house by its global ID, without
+// doing a search. ErrNotFound is returned (wrapped) for unknown IDs.
+func (c *Client) GetHouse(ctx context.Context, globalID int) (*House, error) {
+	if err := c.Validate(); err != nil {
+		return nil, err
+	}
+
+	house := newHouse(globalID)
+	err := c.populateHouseDetails(ctx, house, globalID)
+	if ctx.Err() != nil {
+		return nil, fmt.Errorf("funda: %w", ctx.Err())
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return house, nil
+}
+
+// GetHouses fetches the details of multiple houses by their global IDs
+// concurrently, like a search does. Only the houses that could be fetched are
+// returned, in the order of ids, so the result can be passed to e.g.
+// FilterHouses or WriteCSV as is. The errors of the other houses, which
+// mention their IDs, are joined into the returned error. Once ctx is done, no
+// new fetches are started, and the houses fetched so far are returned with the
+// context's error.
+func (c *Client) GetHouses(ctx context.Context, ids ...int) ([]*House, error) {
+	if err := c.Validate(); err != nil {
+		return nil, err
+	}
+
+	houses := make([]*House, len(ids))
+	for i, id := range ids {
+		houses[i] = newHouse(id)
+	}
+
+	errs := c.fetchAllHouseDetails(ctx, houses)
+	fetched := make([]*House, 0, len(houses))
+	for i, err := range errs {
+		if err != nil {
+			// The error already has the "funda:" prefix.
+			errs[i] = fmt.Errorf("%w (house %v)", err, ids[i])
+			continue
+		}
+		fetched = append(fetched, houses[i])
+	}
+
+	if ctx.Err() != nil {
+		return fetched, fmt.Errorf("funda: %w", ctx.Err())
+	}
+
+	return fetched, errors.Join(errs...)
+}
+
+// Refresh fetches the details of h again, e.g. to detect price changes, and
+// updates h in place. Values from the search result, like the address and
+// photos, are kept. The price values of h are kept as a whole if the details
+// list no price. When the price changed, PreviousPriceEUR is set to the old
+// price, and PriceReduced to whether the price went down; prices on request
+// are not compared. On error, h is left unchanged.
+func (c *Client) Refresh(ctx context.Context, h *House) error {
+	if err := c.Validate(); err != nil {
+		return err
+	}
+
+	// Parse into a new house, so values no longer listed don't linger.
+	house := newHouse(h.ID)
+	house.ListingType = h.ListingType
+	house.IsAd = h.IsAd
+	house.Address = h.Address
+	house.Title = h.Title
+	house.SummaryLines = h.SummaryLines
+	house.ImageURL = h.ImageURL
+	house.ImageURLs = h.ImageURLs
+
+	err := c.populateHouseDetails(ctx, house, h.ID)
+	if ctx.Err() != nil {
+		return fmt.Errorf("funda: %w", ctx.Err())
+	}
+	if err != nil {
+		return err
+	}
+
+	priced := h.PriceEUR != 0 && !h.PriceOnRequest && house.PriceEUR != 0 && !house.PriceOnRequest
+	switch {
+	case house.PriceRaw == "":
+		house.copyPrice(h)
+	case priced && house.PriceEUR != h.PriceEUR:
+		house.PreviousPriceEUR = h.PriceEUR
+		house.PriceReduced = house.PriceEUR < h.PriceEUR
+	case house.PriceEUR == h.PriceEUR && house.PreviousPriceEUR == 0:
+		// An earlier price reduction still applies.
+		house.PreviousPriceEUR = h.PreviousPriceEUR
+		house.PriceReduced = h.PriceReduced
+	}
+
+	*h = *house
+
+	return nil
+}
+
+func (c *Client) populateHouseDetails(ctx context.Context, house *House, globalID int) error {
+	path := fmt.Sprintf("/Aanbod/Detail/%v/%v", house.ListingType.detailPath(), globalID)
+	if c.DetailPathFunc != nil {
+		path = c.DetailPathFunc(globalID)
+	}
+	url := c.BaseURL + path
+	req, err := c.newRequest(ctx, "GET", url, nil)
 	if err != nil {
-		return fmt.Errorf("could not create http request: %e", err)
+		return fmt.Errorf("funda: could not create http request: %w", err)
 	}
 
-	resp, err := c.HTTPClient.Do(req)
+	resp, err := c.do(req, RouteDetail)
 	if err != nil {
-		return fmt.Errorf("funda: could not execute http request: %v", err)
+		return fmt.Errorf("funda: could not execute http request: %w", err)
 	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusOK {
-		return fmt.Errorf(
-			"funda: unexpected HTTP response code (%d) received",
-			resp.StatusCode,
-		)
+		return fmt.Errorf("funda: %w", &StatusError{StatusCode: resp.StatusCode})
 	}
 
-	if err := house.parseDetailsFromAPIResponse(resp.Body); err != nil {
+	if err := house.parseDetailsFromAPIResponse(resp.Body, c.logger(), c.now()); err != nil {
 		return fmt.Errorf(
-			"funda: could not parse house from api response: %v",
+			"funda: could not parse house from api response: %w",
 			err,
 		)
 	}
@@ -210,19 +1053,67 @@ func (c *Client) populateHouseDetails(house *House, globalID int) error {
 	return nil
 }
 
-func (h *House) parseDetailsFromAPIResponse(r io.Reader) error {
+// ParseHouseDetails parses a house detail response body, e.g. as saved from an
+// earlier request, into a new House. Its ID is not part of the response, and
+// is left zero. Relative dates are parsed against the current time; use
+// Client.ParseHouseDetails to set the clock.
+func ParseHouseDetails(r io.Reader) (*House, error) {
+	var c Client
+	return c.ParseHouseDetails(r)
+}
+
+// ParseHouseDetails is like the package-level ParseHouseDetails, parsing
+// relative dates against c.Now and logging skipped list elements to c.Logger.
+func (c *Client) ParseHouseDetails(r io.Reader) (*House, error) {
+	house := newHouse(0)
+	if err := house.parseDetailsFromAPIResponse(r, c.logger(), c.now()); err != nil {
+		return nil, fmt.Errorf("funda: could not parse house details: %w", err)
+	}
+
+	return house, nil
+}
+
+// parseDetailsFromAPIResponse parses a house detail response into h. List
+// elements that can't be decoded are logged to logger and skipped. Relative
+// dates are relative to now.
+func (h *House) parseDetailsFromAPIResponse(r io.Reader, logger Logger, now time.Time) error {
 	var houseResp houseResponse
 	if err := json.NewDecoder(r).Decode(&houseResp); err != nil {
 		return err
 	}
 
+	canonicalURL := false
+
 	for _, item := range houseResp {
-		if item.URL != "" {
+		// Multiple sections may carry a URL; prefer the canonical funda.nl
+		// page over any other.
+		if item.URL != "" && !canonicalURL {
 			houseURL, err := url.Parse(item.URL)
 			if err != nil {
 				return err
 			}
-			h.URL = *houseURL
+			if isCanonicalURL(houseURL) || h.URL.String() == "" {
+				h.URL = *houseURL
+				h.FundaSlug = fundaSlug(houseURL)
+				canonicalURL = isCanonicalURL(houseURL)
+			}
+		}
+
+		// The share section has the title of the listing, being the address
+		// with postal code and city.
+		if subject := normalizeSpace(item.Subject); subject != "" {
+			h.Title = subject
+		}
+
+		if len(item.Makelaars) > 0 && h.Agent.Name == "" {
+			if err := h.Agent.parseMakelaar(item.Makelaars[0]); err != nil {
+				return err
+			}
+		}
+
+		if item.Latitude != 0 || item.Longitude != 0 {
+			h.Latitude = item.Latitude
+			h.Longitude = item.Longitude
 		}
 
 		// Skip photos.
@@ -230,37 +1121,452 @@ func (h *House) parseDetailsFromAPIResponse(r io.Reader) error {
 			continue
 		}
 
+		if item.Section == 2 {
+			h.parseDescription(item)
+			continue
+		}
+
 		for _, l := range item.List {
 			var list houseResponseItemList
 			if err := json.Unmarshal(l, &list); err != nil {
-				return err
+				logger.Printf("Error: Skipping list of house (%v): %v", h.ID, err)
+				continue
 			}
-			if err := h.parseList(list); err != nil {
-				return err
+
+			// The header lists the address as lines of text.
+			if item.Section == 1 {
+				h.parseHeaderLines(list.Line)
 			}
+
+			h.parseList(list, logger, now)
 		}
 	}
 
 	return nil
 }
 
-func (h *House) parseList(list houseResponseItemList) error {
+// parseDescription parses the description section, which holds the text
+// either as a whole or split into list entries.
+func (h *House) parseDescription(item houseResponseItem) {
+	parts := []string{item.Description}
+	for _, l := range item.List {
+		var list houseResponseItemList
+		if err := json.Unmarshal(l, &list); err != nil {
+			continue
+		}
+		parts = append(parts, list.Text)
+	}
+
+	var texts []string
+	for _, part := range parts {
+		part = strings.TrimSpace(strings.ReplaceAll(part, "\r\n", "\n"))
+		if part != "" {
+			texts = append(texts, part)
+		}
+	}
+
+	h.Description = strings.Join(texts, "\n")
+}
+
+// parseSearchInfo parses the summary shown for a search result item, one line
+// per info row. Values parsed from house details take precedence.
+func (h *House) parseSearchInfo(infos []info) {
+	for _, info := range infos {
+		var texts, priceTexts []string
+		for _, line := range info.Line {
+			texts = append(texts, line.Text)
+			if status, ok := parseStatusLabel(line.Text); ok {
+				h.Status = status
+				continue
+			}
+			if !h.parsePreviousPrice(line) {
+				priceTexts = append(priceTexts, line.Text)
+			}
+		}
+		h.SummaryLines = append(h.SummaryLines, strings.Join(texts, " "))
+
+		// The price is given as e.g. "€ 598.011" followed by "k.k.".
+		text := strings.Join(priceTexts, " ")
+		if strings.HasPrefix(text, "€") && h.PriceRaw == "" {
+			h.PriceRaw = text
+			h.PriceEUR, h.PriceCondition, h.PriceOnRequest = parsePrice(text)
+			h.PriceMinEUR, h.PriceMaxEUR = parsePriceRange(text)
+			// Rents are given per month, as in house details.
+			if h.ListingType == ListingRent {
+				h.PricePeriod = PricePeriodMonth
+			}
+		}
+	}
+}
+
+// parsePreviousPrice parses a crossed-out price shown next to the current
+// price of a reduced listing, and returns whether line was one.
+func (h *House) parsePreviousPrice(line houseResponseItemList) bool {
+	if !strings.Contains(line.Css, "line-through") || !strings.HasPrefix(normalizeSpace(line.Text), "€") {
+		return false
+	}
+
+	h.PreviousPriceEUR, _ = parseInt(line.Text)
+	h.PriceReduced = h.PreviousPriceEUR > 0
+
+	return true
+}
+
+func (h *House) parseHeaderLines(lines []houseResponseItemList) {
+	for _, line := range lines {
+		h.parsePreviousPrice(line)
+		if postalCode, city, ok := parsePostalCodeCity(line.Text); ok && h.PostalCode == "" {
+			h.PostalCode = postalCode
+			h.City = city
+		}
+	}
+}
+
+// collectLabels collects all label/value pairs of section, including those of
+// nested lists, into m, which is created when needed.
+func collectLabels(section houseResponseItemList, m *map[string]string) {
+	for _, l := range section.List {
+		var list houseResponseItemList
+		if err := json.Unmarshal(l, &list); err != nil {
+			continue
+		}
+		if list.Label != "" {
+			if *m == nil {
+				*m = make(map[string]string)
+			}
+			(*m)[list.Label] = normalizeSpace(list.Value)
+		}
+		collectLabels(list, m)
+	}
+}
+
+// parseSpecialFeatures parses the "Specifiek" section, e.g. with
+// "Bijzonderheden": "Beschermd stadsgezicht, toegankelijk voor ouderen". The
+// features of all entries are collected.
+func (h *House) parseSpecialFeatures(section houseResponseItemList) {
+	for _, l := range section.List {
+		var list houseResponseItemList
+		if err := json.Unmarshal(l, &list); err != nil {
+			continue
+		}
+		h.SpecialFeatures = append(h.SpecialFeatures, splitList(list.Value)...)
+	}
+}
+
+// parseRoomDetails parses the room breakdown, which lists every room either
+// as a label with its area (e.g. "Woonkamer": "28 m²"), or as a single value
+// (e.g. "Woonkamer 28 m²").
+func (h *House) parseRoomDetails(section houseResponseItemList) {
+	for _, l := range section.List {
+		var list houseResponseItemList
+		if err := json.Unmarshal(l, &list); err != nil {
+			continue
+		}
+
+		s := list.Value
+		if list.Label != "" {
+			s = list.Label + " " + list.Value
+		}
+		if room, ok := parseRoomDetail(s); ok {
+			h.RoomDetails = append(h.RoomDetails, room)
+		}
+	}
+}
+
+// specialConditions are the sale conditions that restrict resale or use of a
+// house, as listed in the details of the listing.
+var specialConditions = []string{
+	"Koopgarant",
+	"Koopstart",
+	"Kopen naar wens",
+	"Slimmer kopen",
+	"Maatschappelijk gebonden eigendom",
+	"Starterswoning",
+	"Zelfbewoningsplicht",
+	"Antispeculatiebeding",
+}
+
+// parseSpecialConditions adds the special conditions mentioned in s to
+// SpecialConditions, once each.
+func (h *House) parseSpecialConditions(s string) {
+	s = strings.ToLower(normalizeSpace(s))
+
+	for _, cond := range specialConditions {
+		if !strings.Contains(s, strings.ToLower(cond)) {
+			continue
+		}
+
+		found := false
+		for _, c := range h.SpecialConditions {
+			found = found || c == cond
+		}
+		if !found {
+			h.SpecialConditions = append(h.SpecialConditions, cond)
+		}
+	}
+}
+
+// parseKitchen parses the kitchen section, with e.g. "Soort keuken": "Open
+// keuken" and "Apparatuur": "Vaatwasser, oven en inductiekookplaat".
+func (h *House) parseKitchen(section houseResponseItemList) {
+	for _, l := range section.List {
+		var list houseResponseItemList
+		if err := json.Unmarshal(l, &list); err != nil {
+			continue
+		}
+
+		switch list.Label {
+		case "Soort keuken", "Type keuken":
+			h.KitchenType = normalizeSpace(list.Value)
+		case "Apparatuur", "Inbouwapparatuur", "Keukenapparatuur":
+			h.KitchenAppliances = append(h.KitchenAppliances, splitList(list.Value)...)
+		}
+	}
+}
+
+// parseGarage parses the garage section, as its labels like "Capaciteit" are
+// also used elsewhere.
+func (h *House) parseGarage(section houseResponseItemList) {
+	for _, l := range section.List {
+		var list houseResponseItemList
+		if err := json.Unmarshal(l, &list); err != nil {
+			continue
+		}
+
+		if list.Label == "Capaciteit" {
+			h.GarageCapacity, _ = parseInt(list.Value)
+		}
+	}
+}
+
+// parseCadastral parses the cadastral section, which lists every parcel as a
+// titled list, e.g. "Amsterdam Q 8224". The designation of the first parcel is
+// kept, and the areas of all parcels are summed.
+func (h *House) parseCadastral(section houseResponseItemList) {
+	for _, l := range section.List {
+		var parcel houseResponseItemList
+		if err := json.Unmarshal(l, &parcel); err != nil || parcel.Title == "" {
+			continue
+		}
+
+		if h.Cadastral.Designation == "" {
+			h.Cadastral.Designation = normalizeSpace(parcel.Title)
+		}
+
+		for _, l := range parcel.List {
+			var list houseResponseItemList
+			if err := json.Unmarshal(l, &list); err != nil {
+				continue
+			}
+			if list.Label == "Oppervlakte" || list.Label == "Kadastrale oppervlakte" {
+				area, _ := parseInt(list.Value)
+				h.Cadastral.AreaM2 += area
+			}
+		}
+	}
+}
+
+func (h *House) parseList(list houseResponseItemList, logger Logger, now time.Time) {
 	for _, l := range list.List {
 		var list houseResponseItemList
 		if err := json.Unmarshal(l, &list); err != nil {
-			return err
+			logger.Printf("Error: Skipping list of house (%v): %v", h.ID, err)
+			continue
+		}
+		h.parseList(list, logger, now)
+	}
+
+	// Keep every label, for those without a typed field. For labels listed
+	// more than once, the first value is kept.
+	if list.Label != "" && list.Value != "" {
+		if h.Attributes == nil {
+			h.Attributes = make(map[string]string)
+		}
+		if _, ok := h.Attributes[list.Label]; !ok {
+			h.Attributes[list.Label] = normalizeSpace(list.Value)
 		}
-		h.parseList(list)
+	}
+
+	if strings.Contains(list.Title, "VvE") {
+		h.HasVvE = true
+		collectLabels(list, &h.VvEDetails)
+	}
+
+	if list.Title == "Oppervlakten en inhoud" {
+		collectLabels(list, &h.Areas)
+	}
+
+	if strings.HasPrefix(list.Title, "Kadastrale") {
+		h.parseCadastral(list)
+	}
+
+	if list.Title == "Specifiek" {
+		h.parseSpecialFeatures(list)
+	}
+
+	if list.Title == "Kamerindeling" || list.Title == "Vertrekken" {
+		h.parseRoomDetails(list)
+	}
+
+	if list.Title == "Keuken" {
+		h.parseKitchen(list)
+	}
+
+	if strings.Contains(list.Title, "Garage") {
+		h.parseGarage(list)
 	}
 
 	switch list.Label {
 	case "Vraagprijs":
-		h.Price = list.Value
+		h.PriceRaw = list.Value
+		h.PriceEUR, h.PriceCondition, h.PriceOnRequest = parsePrice(list.Value)
+		h.PriceMinEUR, h.PriceMaxEUR = parsePriceRange(list.Value)
+	case "Oorspronkelijke vraagprijs", "Oude vraagprijs":
+		h.PreviousPriceEUR, _, _ = parsePrice(list.Value)
+		h.PriceReduced = h.PreviousPriceEUR > 0
+	case "Huurprijs":
+		h.PriceRaw = list.Value
+		h.PriceEUR, _, h.PriceOnRequest = parsePrice(list.Value)
+		h.PriceMinEUR, h.PriceMaxEUR = parsePriceRange(list.Value)
+		h.PricePeriod = PricePeriodMonth
+	case "Servicekosten":
+		h.ServiceCostsEUR, _ = parseInt(list.Value)
 	case "Wonen (= woonoppervlakte)":
-		h.SurfaceArea = list.Value
+		h.SurfaceAreaRaw = list.Value
+		h.LivingAreaM2, _ = parseInt(list.Value)
+	case "Perceel", "Perceeloppervlakte":
+		h.PlotAreaRaw = list.Value
+		h.PlotAreaM2, _ = parseInt(list.Value)
+	case "Inhoud":
+		h.VolumeM3, _ = parseInt(list.Value)
 	case "Aantal kamers":
 		h.Rooms = list.Value
+		h.TotalRooms, h.Bedrooms = parseRooms(list.Value)
+	case "Aantal woonlagen":
+		h.NumberOfFloors, _ = parseInt(list.Value)
+		value := strings.ToLower(list.Value)
+		h.HasAttic = strings.Contains(value, "zolder")
+		h.HasBasement = strings.Contains(value, "kelder")
+	case "Gelegen op":
+		h.Floor = parseFloor(list.Value)
+	case "Aantal badkamers":
+		h.Bathrooms, h.SeparateToilets = parseBathrooms(list.Value)
+	case "Energielabel":
+		// The label class is usually only given as the first line of the
+		// energy label badge, followed by the energy index.
+		label := list.Value
+		if label == "" && len(list.EnergieLabel.Line) > 0 {
+			label = list.EnergieLabel.Line[0].Text
+		}
+		h.EnergyLabel = strings.Join(strings.Fields(normalizeSpace(label)), "")
+	case "Soort woonhuis", "Soort appartement", "Soort object":
+		if h.PropertyType == "" {
+			h.PropertyType = normalizeSpace(list.Value)
+			h.PropertyKind = propertyKindFromLabel(list.Label)
+		}
+	case "Bijdrage VvE":
+		h.HasVvE = true
+		h.VvEMonthlyCostEUR, _ = parseInt(list.Value)
+	case "Tuin":
+		h.Garden.Type = normalizeSpace(list.Value)
+	case "Achtertuin", "Voortuin", "Zijtuin", "Patio/atrium", "Zonneterras":
+		// Sum the areas of all gardens around the house.
+		area, _ := parseInt(list.Value)
+		h.Garden.AreaM2 += area
+	case "Soort parkeergelegenheid":
+		h.Parking = normalizeSpace(list.Value)
+	case "Soort garage":
+		h.GarageType = normalizeSpace(list.Value)
+	case "Schuur/berging":
+		h.ExternalStorage = normalizeSpace(list.Value)
+	case "Balkon/dakterras", "Balkon / dakterras":
+		h.BalconyRaw = normalizeSpace(list.Value)
+		value := strings.ToLower(list.Value)
+		h.Balcony = strings.Contains(value, "balkon")
+		h.RoofTerrace = strings.Contains(value, "dakterras")
+	case "Voorzieningen":
+		facilities := splitList(list.Value)
+		h.Facilities = append(h.Facilities, facilities...)
+		for _, f := range facilities {
+			if strings.Contains(strings.ToLower(f), "lift") {
+				h.HasElevator = true
+			}
+		}
+	case "Lift":
+		value := strings.ToLower(normalizeSpace(list.Value))
+		if value != "" && value != "nee" && value != "geen" {
+			h.HasElevator = true
+		}
+	case "Gemeente":
+		h.Municipality = normalizeSpace(list.Value)
+	case "Buurt":
+		h.Neighborhood = normalizeSpace(list.Value)
+	case "Wijk":
+		// Prefer the more specific buurt, when also listed.
+		if h.Neighborhood == "" {
+			h.Neighborhood = normalizeSpace(list.Value)
+		}
+	case "Ligging":
+		h.Location = splitList(list.Value)
+	case "Ligging tuin":
+		h.Garden.Orientation = parseGardenOrientation(list.Value)
+	case "Status":
+		h.Status = parseListingStatus(list.Value)
+	case "Eigendomssituatie":
+		h.Ownership = normalizeSpace(list.Value)
+		if h.isLeasehold() {
+			h.LeaseholdUntil, _ = parseDate(list.Value)
+		}
+	case "Lasten":
+		if h.isLeasehold() {
+			h.LeaseholdAnnualEUR = parseAnnualCost(list.Value)
+		}
+	case "Aangeboden sinds":
+		h.ListedSince, h.ListedSinceApprox = parseListedSince(list.Value, now)
+	case "Aanvaarding":
+		h.Acceptance = normalizeSpace(list.Value)
+		h.AcceptanceDate, _ = parseDate(list.Value)
+	case "Verwarming":
+		h.Heating = splitList(list.Value)
+	case "Isolatie":
+		h.Insulation = splitList(list.Value)
+		h.InsulationTypes = nil
+		for _, item := range h.Insulation {
+			h.InsulationTypes = append(h.InsulationTypes, parseInsulationType(item))
+		}
+	case "Soort bouw", "Bouwvorm":
+		h.ConstructionType = normalizeSpace(list.Value)
+	case "Bouwjaar":
+		h.YearBuilt, h.YearBuiltApprox = parseYear(list.Value)
 	}
 
-	return nil
+	if !strings.EqualFold(normalizeSpace(list.Value), "Nee") {
+		h.parseSpecialConditions(list.Label + " " + list.Value)
+	}
+
+	// Solar panels and heat pumps are listed among the facilities, or in the
+	// energy section.
+	switch list.Label {
+	case "Voorzieningen", "Specifieke voorzieningen", "Verwarming", "Warm water", "Isolatie":
+		value := strings.ToLower(list.Value)
+		if strings.Contains(value, "zonnepane") || strings.Contains(value, "zonnecollector") {
+			h.SolarPanels = true
+		}
+		if strings.Contains(value, "warmtepomp") {
+			h.HeatPump = true
+		}
+	}
+
+	// Attic and basement areas are listed as sub-entries of the areas or
+	// floors, e.g. "Zolder": "12 m²".
+	if strings.Contains(list.Value, "m²") {
+		label := strings.ToLower(list.Label)
+		area, _ := parseInt(list.Value)
+		switch {
+		case strings.Contains(label, "zolder"):
+			h.AtticAreaM2 += area
+		case strings.Contains(label, "kelder"):
+			h.BasementAreaM2 += area
+		}
+	}
 }
@@ -2,12 +2,24 @@ package funda
 
 import (
 	"bufio"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"errors"
 	"io"
+	"math"
 	"net/http"
+	"net/http/cookiejar"
 	"net/http/httptest"
 	"net/url"
 	"os"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync/atomic"
 	"testing"
+	"time"
 )
 
 func TestParseDetailsFromAPIResponse(t *testing.T) {
@@ -34,15 +46,115 @@ func TestParseDetailsFromAPIResponse(t *testing.T) {
 
 	fundaClient := NewClient("foobar")
 	fundaClient.BaseURL = ts.URL
+	fundaClient.Now = func() time.Time {
+		return time.Date(2018, time.March, 15, 12, 0, 0, 0, amsterdam)
+	}
 
 	exp := House{
-		ID:          4094475,
-		Address:     "Buiksloterbreek 65",
-		Price:       "€ 400.000 k.k.",
-		URL:         parseURL("https://www.funda.nl/40443683"),
-		ImageURL:    parseURL("https://cloud.funda.nl/valentina_media/090/700/422_720x480.jpg"),
-		SurfaceArea: "68 m²",
-		Rooms:       "3 kamers (1 slaapkamer)",
+		ID:         4094475,
+		Status:     StatusUnderBid,
+		Address:    "Buiksloterbreek 65",
+		Title:      "De Clercqstraat 20 1, 1052 ND Amsterdam",
+		PostalCode: "1052 ND",
+		City:       "Amsterdam",
+		SummaryLines: []string{
+			"Buiksloterbreek 65",
+			"1034 XD  Amsterdam",
+			"131 m² / 195 m² • 5 kamers",
+			"€ 598.011 k.k.",
+		},
+		PriceRaw:        "€ 400.000 k.k.",
+		PriceEUR:        400000,
+		PriceMinEUR:     400000,
+		PriceMaxEUR:     400000,
+		ServiceCostsEUR: 96,
+		PriceCondition:  PriceConditionKostenKoper,
+		URL:             parseURL("https://www.funda.nl/40443683"),
+		FundaSlug:       "40443683",
+		ImageURL:        parseURL("https://cloud.funda.nl/valentina_media/090/700/422_720x480.jpg"),
+		ImageURLs: []url.URL{
+			parseURL("https://cloud.funda.nl/valentina_media/090/700/422_720x480.jpg"),
+			parseURL("https://cloud.funda.nl/valentina_media/090/700/422_1080x720.jpg"),
+			parseURL("https://cloud.funda.nl/valentina_media/090/700/422_1440x960.jpg"),
+			parseURL("https://cloud.funda.nl/valentina_media/090/700/422_2160x1440.jpg"),
+		},
+		SurfaceAreaRaw: "68 m²",
+		LivingAreaM2:   68,
+		VolumeM3:       230,
+		Rooms:          "3 kamers (1 slaapkamer)",
+		TotalRooms:     3,
+		Bedrooms:       1,
+		Floor:          1,
+		NumberOfFloors: 1,
+		Latitude:       52.371685,
+		Longitude:      4.872972,
+		Agent:          Agent{Name: "Zelfverkopen.nl", Phone: "088 235 0111"},
+		Cadastral:      Cadastral{Designation: "Amsterdam Q 8224"},
+		PropertyType:   "Bovenwoning (appartement)",
+		PropertyKind:   PropertyKindApartment,
+		EnergyLabel:    "D",
+		Heating:        []string{"C.V.-ketel"},
+		YearBuilt:      1906,
+		HasVvE:         true,
+		VvEDetails: map[string]string{
+			"Inschrijving KvK":       "Ja",
+			"Jaarlijkse vergadering": "Ja",
+			"Periodieke bijdrage":    "Ja",
+			"Reservefonds aanwezig":  "Ja",
+			"Onderhoudsplan":         "Nee",
+			"Opstalverzekering":      "Ja",
+		},
+		Acceptance: "Per direct beschikbaar",
+		// Relative to the client's clock ("2 maanden").
+		ListedSince:       time.Date(2018, time.January, 15, 0, 0, 0, 0, amsterdam),
+		ListedSinceApprox: true,
+		Attributes: map[string]string{
+			"Aangeboden sinds":            "2 maanden",
+			"Aantal kamers":               "3 kamers (1 slaapkamer)",
+			"Aantal woonlagen":            "1 woonlaag",
+			"Aanvaarding":                 "Per direct beschikbaar",
+			"Balkon / dakterras":          "Balkon aanwezig",
+			"Bouwjaar":                    "1906",
+			"Bouwvorm":                    "Bestaande bouw",
+			"C.V.-ketel":                  "Intergas (gas gestookt combiketel uit 2015, Eigendom)",
+			"Eigendomssituatie":           "Gemeentelijk eigendom belast met erfpacht (einddatum erfpacht:  31-07-2024)",
+			"Externe bergruimte":          "6 m²",
+			"Gebouwgebonden buitenruimte": "4 m²",
+			"Gelegen op":                  "1e woonlaag",
+			"Inhoud":                      "230 m³",
+			"Inschrijving KvK":            "Ja",
+			"Jaarlijkse vergadering":      "Ja",
+			"Lasten":                      "€ 127,86 per jaar",
+			"Ligging":                     "Aan water, aan drukke weg, aan rustige weg, in centrum, in woonwijk, vrij uitzicht en aan vaarwater",
+			"Onderhoudsplan":              "Nee",
+			"Opstalverzekering":           "Ja",
+			"Periodieke bijdrage":         "Ja",
+			"Reservefonds aanwezig":       "Ja",
+			"Schuur/berging":              "Inpandig",
+			"Servicekosten":               "€ 96 /mnd",
+			"Soort appartement":           "Bovenwoning (appartement)",
+			"Status":                      "Onder bod",
+			"Verwarming":                  "C.V.-ketel",
+			"Voorzieningen":               "Dakraam en TV kabel",
+			"Vraagprijs":                  "€ 400.000 k.k.",
+			"Warm water":                  "C.V.-ketel",
+			"Wonen (= woonoppervlakte)":   "68 m²",
+		},
+		Areas: map[string]string{
+			"Wonen (= woonoppervlakte)":   "68 m²",
+			"Gebouwgebonden buitenruimte": "4 m²",
+			"Externe bergruimte":          "6 m²",
+			"Inhoud":                      "230 m³",
+		},
+		ConstructionType:   "Bestaande bouw",
+		Facilities:         []string{"Dakraam", "TV kabel", "Elektra"},
+		Ownership:          "Gemeentelijk eigendom belast met erfpacht (einddatum erfpacht:  31-07-2024)",
+		LeaseholdUntil:     time.Date(2024, time.July, 31, 0, 0, 0, 0, amsterdam),
+		LeaseholdAnnualEUR: 127,
+		BalconyRaw:         "Balkon aanwezig",
+		Balcony:            true,
+		Location:           []string{"Aan water", "aan drukke weg", "aan rustige weg", "in centrum", "in woonwijk", "vrij uitzicht", "aan vaarwater"},
+		ExternalStorage:    "Inpandig",
 	}
 
 	got, err := fundaClient.Search("", 0, 0)
@@ -54,7 +166,15 @@ func TestParseDetailsFromAPIResponse(t *testing.T) {
 		t.Fatalf("Got: %v houses, expected %v", len(got), 1)
 	}
 
-	if *got[0] != exp {
+	// The description is long, so only check how it starts and ends.
+	desc := got[0].Description
+	if !strings.HasPrefix(desc, "(FOR ENGLISH SEE BELOW)\n\nUniek en comfortabel") ||
+		!strings.HasSuffix(desc, "Ceiling fan in the bedroom") {
+		t.Fatalf("Got description: %q", desc)
+	}
+	exp.Description = desc
+
+	if !reflect.DeepEqual(*got[0], exp) {
 		t.Fatalf("Got: %+v, expected %+v", *got[0], exp)
 	}
 }
@@ -67,3 +187,1307 @@ func parseURL(s string) url.URL {
 
 	return *u
 }
+
+func TestSearchContextCancel(t *testing.T) {
+	searchResp, err := os.ReadFile("test_data/funda_search_response.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasPrefix(r.URL.Path, "/Aanbod/Detail/") {
+			cancel()
+			<-r.Context().Done()
+			return
+		}
+		w.Write(searchResp)
+	}))
+	defer ts.Close()
+
+	fundaClient := NewClient("foobar")
+	fundaClient.BaseURL = ts.URL
+
+	_, err = fundaClient.SearchContext(ctx, "", 0, 0)
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("Got: %v, expected %v", err, context.Canceled)
+	}
+}
+
+func TestSearchListingsRent(t *testing.T) {
+	responses := map[string]string{
+		"/Aanbod/huur":                "test_data/funda_search_response.json",
+		"/Aanbod/Detail/Huur/4094475": "test_data/funda_house_response.json",
+	}
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		file, ok := responses[r.URL.Path]
+		if !ok {
+			t.Errorf("Unexpected request path: %v", r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		http.ServeFile(w, r, file)
+	}))
+	defer ts.Close()
+
+	fundaClient := NewClient("foobar")
+	fundaClient.BaseURL = ts.URL
+
+	got, err := fundaClient.SearchListings(ListingRent, "", 0, 0)
+	if err != nil {
+		t.Fatalf("Got: %v, expected %v", err, nil)
+	}
+
+	if len(got) != 1 {
+		t.Fatalf("Got: %v houses, expected %v", len(got), 1)
+	}
+
+	if got[0].ListingType != ListingRent {
+		t.Fatalf("Got: %v, expected %v", got[0].ListingType, ListingRent)
+	}
+
+	// Without details, the rent period follows from the listing type.
+	fundaClient.SkipDetails = true
+
+	got, err = fundaClient.SearchListings(ListingRent, "", 0, 0)
+	if err != nil {
+		t.Fatalf("Got: %v, expected %v", err, nil)
+	}
+	if len(got) != 1 || got[0].PricePeriod != PricePeriodMonth {
+		t.Fatalf("Got: %v, expected a monthly rent", got)
+	}
+}
+
+func TestSearchAll(t *testing.T) {
+	searchResp, err := os.ReadFile("test_data/funda_search_response.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var pages []string
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasPrefix(r.URL.Path, "/Aanbod/Detail/") {
+			http.ServeFile(w, r, "test_data/funda_house_response.json")
+			return
+		}
+
+		page := r.URL.Query().Get("page")
+		pages = append(pages, page)
+
+		// The first two pages repeat the same listing, the third is empty.
+		if page == "3" {
+			w.Write([]byte("[]"))
+			return
+		}
+		w.Write(searchResp)
+	}))
+	defer ts.Close()
+
+	fundaClient := NewClient("foobar")
+	fundaClient.BaseURL = ts.URL
+
+	got, err := fundaClient.SearchAll("", 25)
+	if err != nil {
+		t.Fatalf("Got: %v, expected %v", err, nil)
+	}
+
+	if len(got) != 1 {
+		t.Fatalf("Got: %v houses, expected %v", len(got), 1)
+	}
+
+	if exp := []string{"1", "2", "3"}; strings.Join(pages, ",") != strings.Join(exp, ",") {
+		t.Fatalf("Got pages: %v, expected %v", pages, exp)
+	}
+}
+
+func TestSearchDetailConcurrency(t *testing.T) {
+	ids := []int{1, 2, 3, 4, 5, 6, 7, 8}
+	searchResp := searchResponseWithIDs(t, ids...)
+
+	var inFlight, maxInFlight int32
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !strings.HasPrefix(r.URL.Path, "/Aanbod/Detail/") {
+			w.Write(searchResp)
+			return
+		}
+
+		n := atomic.AddInt32(&inFlight, 1)
+		defer atomic.AddInt32(&inFlight, -1)
+		for {
+			max := atomic.LoadInt32(&maxInFlight)
+			if n <= max || atomic.CompareAndSwapInt32(&maxInFlight, max, n) {
+				break
+			}
+		}
+		time.Sleep(10 * time.Millisecond)
+
+		// Fail a single house, which should be skipped.
+		if r.URL.Path == "/Aanbod/Detail/Koop/3" {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		http.ServeFile(w, r, "test_data/funda_house_response.json")
+	}))
+	defer ts.Close()
+
+	fundaClient := NewClient("foobar")
+	fundaClient.BaseURL = ts.URL
+	fundaClient.DetailConcurrency = 2
+
+	got, err := fundaClient.Search("", 0, 0)
+	if err != nil {
+		t.Fatalf("Got: %v, expected %v", err, nil)
+	}
+
+	var gotIDs []int
+	for _, house := range got {
+		gotIDs = append(gotIDs, house.ID)
+	}
+	if exp := []int{1, 2, 4, 5, 6, 7, 8}; !reflect.DeepEqual(gotIDs, exp) {
+		t.Fatalf("Got: %v, expected %v", gotIDs, exp)
+	}
+
+	if maxInFlight > 2 {
+		t.Fatalf("Got: %v concurrent requests, expected at most %v", maxInFlight, 2)
+	}
+}
+
+// searchResponseWithIDs returns a search response body containing a copy of
+// the test data search result item for each of the given global IDs.
+func searchResponseWithIDs(t *testing.T, ids ...int) []byte {
+	data, err := os.ReadFile("test_data/funda_search_response.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var items []map[string]interface{}
+	if err := json.Unmarshal(data, &items); err != nil {
+		t.Fatal(err)
+	}
+
+	var result []map[string]interface{}
+	for _, id := range ids {
+		item := make(map[string]interface{})
+		for k, v := range items[0] {
+			item[k] = v
+		}
+		item["GlobalId"] = id
+		result = append(result, item)
+	}
+
+	body, err := json.Marshal(result)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return body
+}
+
+// searchResponseWithSold is like searchResponseWithIDs, with the listings of
+// the given IDs labeled as sold next to their price.
+func searchResponseWithSold(t *testing.T, ids []int, sold ...int) []byte {
+	var items []map[string]interface{}
+	if err := json.Unmarshal(searchResponseWithIDs(t, ids...), &items); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, item := range items {
+		for _, id := range sold {
+			if int(item["GlobalId"].(float64)) != id {
+				continue
+			}
+			infos := item["Info"].([]interface{})
+			price := infos[len(infos)-1].(map[string]interface{})
+			price["Line"] = append(price["Line"].([]interface{}), map[string]interface{}{"Text": "Verkocht"})
+		}
+	}
+
+	body, err := json.Marshal(items)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return body
+}
+
+func TestSearchExcludeSold(t *testing.T) {
+	searchResp := searchResponseWithSold(t, []int{1, 2}, 2)
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/Aanbod/Detail/Koop/2" {
+			t.Errorf("Unexpected request path: %v", r.URL.Path)
+		}
+		if strings.HasPrefix(r.URL.Path, "/Aanbod/Detail/") {
+			http.ServeFile(w, r, "test_data/funda_house_response.json")
+			return
+		}
+		w.Write(searchResp)
+	}))
+	defer ts.Close()
+
+	fundaClient := NewClient("foobar")
+	fundaClient.BaseURL = ts.URL
+	fundaClient.ExcludeSold = true
+
+	got, err := fundaClient.Search("", 0, 0)
+	if err != nil {
+		t.Fatalf("Got: %v, expected %v", err, nil)
+	}
+	if len(got) != 1 || got[0].ID != 1 {
+		t.Fatalf("Got: %v, expected only house 1", got)
+	}
+}
+
+func TestSearchAllExcludeSoldPage(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Query().Get("page") {
+		case "1":
+			w.Write(searchResponseWithSold(t, []int{1}, 1))
+		case "2":
+			w.Write(searchResponseWithIDs(t, 2))
+		default:
+			w.Write([]byte("[]"))
+		}
+	}))
+	defer ts.Close()
+
+	fundaClient := NewClient("foobar")
+	fundaClient.BaseURL = ts.URL
+	fundaClient.ExcludeSold = true
+	fundaClient.SkipDetails = true
+
+	got, err := fundaClient.SearchAll("", 1)
+	if err != nil {
+		t.Fatalf("Got: %v, expected %v", err, nil)
+	}
+	if len(got) != 1 || got[0].ID != 2 {
+		t.Fatalf("Got: %v, expected only house 2", got)
+	}
+}
+
+func TestSearchSkipsIncompleteResults(t *testing.T) {
+	searchResp := []byte(`[{"ItemType":1,"GlobalId":1,"Info":[{"Line":[{"Text":"Incomplete"}]}]}]`)
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasPrefix(r.URL.Path, "/Aanbod/Detail/") {
+			t.Errorf("Unexpected request path: %v", r.URL.Path)
+		}
+		w.Write(searchResp)
+	}))
+	defer ts.Close()
+
+	fundaClient := NewClient("foobar")
+	fundaClient.BaseURL = ts.URL
+
+	got, err := fundaClient.Search("", 0, 0)
+	if err != nil {
+		t.Fatalf("Got: %v, expected %v", err, nil)
+	}
+	if len(got) != 0 {
+		t.Fatalf("Got: %v houses, expected %v", len(got), 0)
+	}
+
+	fundaClient.StrictParsing = true
+
+	if _, err := fundaClient.Search("", 0, 0); err == nil {
+		t.Fatalf("Got: %v, expected an error", err)
+	}
+}
+
+func TestSearchSkipsInvalidPhotos(t *testing.T) {
+	var items []map[string]interface{}
+	if err := json.Unmarshal(searchResponseWithIDs(t, 1), &items); err != nil {
+		t.Fatal(err)
+	}
+	items[0]["Fotos"] = []map[string]string{
+		{"Link": "http://example.com/%zz.jpg"},
+		{"Link": "http://example.com/1.jpg"},
+	}
+
+	searchResp, err := json.Marshal(items)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write(searchResp)
+	}))
+	defer ts.Close()
+
+	fundaClient := NewClient("foobar")
+	fundaClient.BaseURL = ts.URL
+	fundaClient.SkipDetails = true
+
+	got, err := fundaClient.Search("", 0, 0)
+	if err != nil {
+		t.Fatalf("Got: %v, expected %v", err, nil)
+	}
+	if len(got) != 1 || len(got[0].ImageURLs) != 1 || got[0].ImageURL.String() != "http://example.com/1.jpg" {
+		t.Fatalf("Got: %v, expected house 1 with only the valid photo", got)
+	}
+
+	fundaClient.StrictParsing = true
+
+	if _, err := fundaClient.Search("", 0, 0); err == nil {
+		t.Fatalf("Got: %v, expected an error", err)
+	}
+}
+
+func TestSearchRetries(t *testing.T) {
+	searchResp, err := os.ReadFile("test_data/funda_search_response.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name     string
+		status   int
+		failures int
+		expReqs  int
+		expErr   bool
+	}{
+		{"retries 5xx", http.StatusServiceUnavailable, 2, 3, false},
+		{"gives up after max retries", http.StatusBadGateway, 5, 3, true},
+		{"does not retry 4xx", http.StatusNotFound, 1, 1, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var reqs int
+
+			ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if strings.HasPrefix(r.URL.Path, "/Aanbod/Detail/") {
+					http.ServeFile(w, r, "test_data/funda_house_response.json")
+					return
+				}
+				reqs++
+				if reqs <= tt.failures {
+					w.WriteHeader(tt.status)
+					return
+				}
+				w.Write(searchResp)
+			}))
+			defer ts.Close()
+
+			fundaClient := NewClient("foobar")
+			fundaClient.BaseURL = ts.URL
+			fundaClient.MaxRetries = 2
+			fundaClient.RetryBackoff = time.Millisecond
+
+			_, err := fundaClient.Search("", 0, 0)
+			if (err != nil) != tt.expErr {
+				t.Fatalf("Got: %v, expected error: %v", err, tt.expErr)
+			}
+			if reqs != tt.expReqs {
+				t.Fatalf("Got: %v requests, expected %v", reqs, tt.expReqs)
+			}
+		})
+	}
+}
+
+func TestRetryBackoff(t *testing.T) {
+	tests := []struct {
+		name    string
+		backoff time.Duration
+		attempt int
+		min     time.Duration
+		max     time.Duration
+	}{
+		{"first attempt", time.Second, 0, time.Second, 1500 * time.Millisecond},
+		{"third attempt", time.Second, 2, 4 * time.Second, 6 * time.Second},
+		{"capped", time.Second, 40, maxRetryBackoff, maxRetryBackoff * 3 / 2},
+		{"capped large backoff", time.Hour, 1, maxRetryBackoff, maxRetryBackoff * 3 / 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fundaClient := NewClient("foobar")
+			fundaClient.RetryBackoff = tt.backoff
+
+			got := fundaClient.retryBackoff(tt.attempt)
+			if got < tt.min || got > tt.max {
+				t.Fatalf("Got: %v, expected between %v and %v", got, tt.min, tt.max)
+			}
+		})
+	}
+}
+
+type countingLimiter struct {
+	n int32
+}
+
+func (l *countingLimiter) Wait(ctx context.Context) error {
+	atomic.AddInt32(&l.n, 1)
+	return ctx.Err()
+}
+
+func TestSearchRateLimiter(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasPrefix(r.URL.Path, "/Aanbod/Detail/") {
+			http.ServeFile(w, r, "test_data/funda_house_response.json")
+			return
+		}
+		w.Write(searchResponseWithIDs(t, 1, 2, 3))
+	}))
+	defer ts.Close()
+
+	limiter := &countingLimiter{}
+
+	fundaClient := NewClient("foobar")
+	fundaClient.BaseURL = ts.URL
+	fundaClient.RateLimiter = limiter
+
+	if _, err := fundaClient.Search("", 0, 0); err != nil {
+		t.Fatalf("Got: %v, expected %v", err, nil)
+	}
+
+	// One search request and three detail requests.
+	if limiter.n != 4 {
+		t.Fatalf("Got: %v waits, expected %v", limiter.n, 4)
+	}
+}
+
+func TestNewRequestHeaders(t *testing.T) {
+	fundaClient := NewClient("foobar")
+
+	req, err := fundaClient.newRequest(context.Background(), "GET", "http://example.com", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := req.Header.Get("User-Agent"); got != defaultUserAgent {
+		t.Fatalf("Got: %v, expected %v", got, defaultUserAgent)
+	}
+
+	fundaClient.UserAgent = "go-funda"
+	fundaClient.ExtraHeaders = http.Header{
+		"X-Trace-Id": []string{"abc"},
+		"Accept":     []string{"application/vnd.funda+json"},
+	}
+
+	req, err = fundaClient.newRequest(context.Background(), "GET", "http://example.com", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	exp := map[string]string{
+		"User-Agent": "go-funda",
+		"X-Trace-Id": "abc",
+		"Accept":     "application/vnd.funda+json",
+		"Api_key":    "foobar",
+	}
+	for key, value := range exp {
+		if got := req.Header.Get(key); got != value {
+			t.Fatalf("Got %v: %v, expected %v", key, got, value)
+		}
+	}
+}
+
+func TestSearchStatusErrors(t *testing.T) {
+	tests := []struct {
+		status int
+		exp    error
+	}{
+		{http.StatusUnauthorized, ErrUnauthorized},
+		{http.StatusNotFound, ErrNotFound},
+		{http.StatusTooManyRequests, ErrRateLimited},
+	}
+
+	for _, tt := range tests {
+		ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(tt.status)
+		}))
+
+		fundaClient := NewClient("foobar")
+		fundaClient.BaseURL = ts.URL
+
+		_, err := fundaClient.Search("", 0, 0)
+		ts.Close()
+
+		if !errors.Is(err, tt.exp) {
+			t.Errorf("Got: %v, expected %v", err, tt.exp)
+		}
+
+		var statusErr *StatusError
+		if !errors.As(err, &statusErr) || statusErr.StatusCode != tt.status {
+			t.Errorf("Got: %v, expected status code %v", err, tt.status)
+		}
+	}
+}
+
+func TestSearchMissingAPIKey(t *testing.T) {
+	fundaClient := NewClient("")
+	fundaClient.BaseURL = "http://127.0.0.1:0"
+
+	if _, err := fundaClient.Search("", 0, 0); !errors.Is(err, ErrMissingAPIKey) {
+		t.Fatalf("Got: %v, expected %v", err, ErrMissingAPIKey)
+	}
+}
+
+func TestGetHouse(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/Aanbod/Detail/Koop/4094475" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		http.ServeFile(w, r, "test_data/funda_house_response.json")
+	}))
+	defer ts.Close()
+
+	fundaClient := NewClient("foobar")
+	fundaClient.BaseURL = ts.URL
+
+	got, err := fundaClient.GetHouse(context.Background(), 4094475)
+	if err != nil {
+		t.Fatalf("Got: %v, expected %v", err, nil)
+	}
+	if got.ID != 4094475 || got.PriceEUR != 400000 {
+		t.Fatalf("Got: %+v, expected populated house", got)
+	}
+
+	if _, err := fundaClient.GetHouse(context.Background(), 1); !errors.Is(err, ErrNotFound) {
+		t.Fatalf("Got: %v, expected %v", err, ErrNotFound)
+	}
+}
+
+func TestRefresh(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/Aanbod/Detail/Koop/4094475":
+			http.ServeFile(w, r, "test_data/funda_house_response.json")
+		case "/Aanbod/Detail/Koop/2":
+			w.Write([]byte(`[{"Section":12,"List":[{"Label":"Bouwjaar","Value":"1930"}]}]`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer ts.Close()
+
+	fundaClient := NewClient("foobar")
+	fundaClient.BaseURL = ts.URL
+
+	h := &House{
+		ID:         4094475,
+		Address:    "De Clercqstraat 20 1",
+		PriceEUR:   425000,
+		Facilities: []string{"Lift"},
+	}
+	if err := fundaClient.Refresh(context.Background(), h); err != nil {
+		t.Fatalf("Got: %v, expected %v", err, nil)
+	}
+
+	if h.PriceEUR != 400000 || h.PreviousPriceEUR != 425000 || !h.PriceReduced {
+		t.Fatalf("Got: %v, %v, %v; expected %v, %v, %v", h.PriceEUR, h.PreviousPriceEUR, h.PriceReduced, 400000, 425000, true)
+	}
+	if h.Address != "De Clercqstraat 20 1" {
+		t.Fatalf("Got: %q, expected address to be kept", h.Address)
+	}
+	if exp := []string{"Dakraam", "TV kabel", "Elektra"}; !reflect.DeepEqual(h.Facilities, exp) {
+		t.Fatalf("Got: %q, expected %q", h.Facilities, exp)
+	}
+
+	// An earlier reduction is kept while the price is unchanged.
+	reduced := &House{ID: 4094475, PriceRaw: "€ 400.000 k.k.", PriceEUR: 400000, PreviousPriceEUR: 425000, PriceReduced: true}
+	if err := fundaClient.Refresh(context.Background(), reduced); err != nil {
+		t.Fatalf("Got: %v, expected %v", err, nil)
+	}
+	if reduced.PreviousPriceEUR != 425000 || !reduced.PriceReduced {
+		t.Fatalf("Got: %v, %v; expected %v, %v", reduced.PreviousPriceEUR, reduced.PriceReduced, 425000, true)
+	}
+
+	// Without a price in the details, all price values are kept, while the
+	// status is no longer listed.
+	searched := &House{
+		ID:             2,
+		Status:         StatusSold,
+		PriceRaw:       "€ 598.011 k.k.",
+		PriceEUR:       598011,
+		PriceMinEUR:    598011,
+		PriceMaxEUR:    598011,
+		PriceCondition: PriceConditionKostenKoper,
+	}
+	var exp House
+	exp.copyPrice(searched)
+	if err := fundaClient.Refresh(context.Background(), searched); err != nil {
+		t.Fatalf("Got: %v, expected %v", err, nil)
+	}
+	var got House
+	got.copyPrice(searched)
+	if !reflect.DeepEqual(got, exp) {
+		t.Fatalf("Got: %+v, expected %+v", got, exp)
+	}
+	if searched.Status != StatusAvailable || searched.YearBuilt != 1930 {
+		t.Fatalf("Got: %v, %v; expected %v, %v", searched.Status, searched.YearBuilt, StatusAvailable, 1930)
+	}
+
+	unknown := &House{ID: 1, PriceEUR: 100000}
+	if err := fundaClient.Refresh(context.Background(), unknown); !errors.Is(err, ErrNotFound) {
+		t.Fatalf("Got: %v, expected %v", err, ErrNotFound)
+	}
+	if unknown.PriceEUR != 100000 {
+		t.Fatalf("Got: %v, expected house to be unchanged", unknown.PriceEUR)
+	}
+}
+
+func TestRefreshPriceOnRequest(t *testing.T) {
+	houseResp, err := os.ReadFile("test_data/funda_house_response.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	houseResp = bytes.ReplaceAll(houseResp, []byte("€\u00a0400.000 k.k."), []byte("Prijs op aanvraag"))
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write(houseResp)
+	}))
+	defer ts.Close()
+
+	fundaClient := NewClient("foobar")
+	fundaClient.BaseURL = ts.URL
+
+	h := &House{ID: 4094475, PriceEUR: 425000}
+	if err := fundaClient.Refresh(context.Background(), h); err != nil {
+		t.Fatalf("Got: %v, expected %v", err, nil)
+	}
+
+	if !h.PriceOnRequest || h.PreviousPriceEUR != 0 || h.PriceReduced {
+		t.Fatalf("Got: %v, %v, %v; expected %v, %v, %v", h.PriceOnRequest, h.PreviousPriceEUR, h.PriceReduced, true, 0, false)
+	}
+}
+
+func TestGetHouses(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/Aanbod/Detail/Koop/2" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		http.ServeFile(w, r, "test_data/funda_house_response.json")
+	}))
+	defer ts.Close()
+
+	fundaClient := NewClient("foobar")
+	fundaClient.BaseURL = ts.URL
+	fundaClient.DetailConcurrency = 2
+
+	got, err := fundaClient.GetHouses(context.Background(), 1, 2, 3)
+	if !errors.Is(err, ErrNotFound) {
+		t.Fatalf("Got: %v, expected %v", err, ErrNotFound)
+	}
+	if exp := "funda: unexpected HTTP response code (404) received (house 2)"; err.Error() != exp {
+		t.Fatalf("Got: %q, expected %q", err, exp)
+	}
+	if len(got) != 2 || got[0].ID != 1 || got[1].ID != 3 {
+		t.Fatalf("Got: %v, expected houses 1 and 3", got)
+	}
+}
+
+func TestGetHousesContextCancel(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	var reqs int32
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&reqs, 1)
+		if r.URL.Path == "/Aanbod/Detail/Koop/2" {
+			cancel()
+			<-r.Context().Done()
+			return
+		}
+		http.ServeFile(w, r, "test_data/funda_house_response.json")
+	}))
+	defer ts.Close()
+
+	fundaClient := NewClient("foobar")
+	fundaClient.BaseURL = ts.URL
+	fundaClient.DetailConcurrency = 1
+
+	got, err := fundaClient.GetHouses(ctx, 1, 2, 3)
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("Got: %v, expected %v", err, context.Canceled)
+	}
+	if len(got) != 1 || got[0].ID != 1 {
+		t.Fatalf("Got: %v, expected only house 1", got)
+	}
+	if n := atomic.LoadInt32(&reqs); n != 2 {
+		t.Fatalf("Got: %v requests, expected %v", n, 2)
+	}
+}
+
+func TestDetailPathFunc(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/Aanbod/Detail/Nieuwbouw/4094475" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		http.ServeFile(w, r, "test_data/funda_house_response.json")
+	}))
+	defer ts.Close()
+
+	fundaClient := NewClient("foobar")
+	fundaClient.BaseURL = ts.URL
+	fundaClient.DetailPathFunc = func(globalID int) string {
+		return "/Aanbod/Detail/Nieuwbouw/" + strconv.Itoa(globalID)
+	}
+
+	got, err := fundaClient.GetHouse(context.Background(), 4094475)
+	if err != nil {
+		t.Fatalf("Got: %v, expected %v", err, nil)
+	}
+	if got.PriceEUR != 400000 {
+		t.Fatalf("Got: %+v, expected populated house", got)
+	}
+}
+
+func TestCookieJar(t *testing.T) {
+	var reqs int
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		reqs++
+		if reqs == 1 {
+			if got := r.Header.Get("Cookie"); got != "consent=1" {
+				t.Errorf("Got Cookie: %v, expected %v", got, "consent=1")
+			}
+			http.SetCookie(w, &http.Cookie{Name: "session", Value: "abc"})
+		} else if _, err := r.Cookie("session"); err != nil {
+			t.Errorf("Expected session cookie, got: %v", r.Header.Get("Cookie"))
+		}
+		w.Write([]byte("[]"))
+	}))
+	defer ts.Close()
+
+	jar, err := cookiejar.New(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	fundaClient := NewClient("foobar")
+	fundaClient.BaseURL = ts.URL
+	fundaClient.CookieJar = jar
+	fundaClient.ExtraHeaders = http.Header{"Cookie": []string{"consent=1"}}
+
+	for i := 0; i < 2; i++ {
+		if _, err := fundaClient.Search("", 0, 0); err != nil {
+			t.Fatalf("Got: %v, expected %v", err, nil)
+		}
+	}
+}
+
+func TestCookieJarRedirect(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if n := strings.Count(r.Header.Get("Cookie"), "session="); n > 1 {
+			t.Errorf("Got Cookie: %q, expected session cookie once", r.Header.Get("Cookie"))
+		}
+		if r.URL.Path != "/redirected" {
+			http.SetCookie(w, &http.Cookie{Name: "session", Value: "abc", Path: "/"})
+			http.Redirect(w, r, "/redirected", http.StatusFound)
+			return
+		}
+		if _, err := r.Cookie("session"); err != nil {
+			t.Errorf("Expected session cookie, got: %v", r.Header.Get("Cookie"))
+		}
+		w.Write([]byte("[]"))
+	}))
+	defer ts.Close()
+
+	jar, err := cookiejar.New(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	fundaClient := NewClient("foobar")
+	fundaClient.BaseURL = ts.URL
+	fundaClient.HTTPClient = &http.Client{Jar: jar}
+	fundaClient.CookieJar = jar
+
+	for i := 0; i < 2; i++ {
+		if _, err := fundaClient.Search("", 0, 0); err != nil {
+			t.Fatalf("Got: %v, expected %v", err, nil)
+		}
+	}
+}
+
+func TestSearchSortOrder(t *testing.T) {
+	tests := []struct {
+		order SortOrder
+		exp   string
+	}{
+		{SortOrderDefault, ""},
+		{SortOrderNewest, "date_down"},
+		{SortOrderPriceAsc, "price_up"},
+		{SortOrderPriceDesc, "price_down"},
+	}
+
+	for _, tt := range tests {
+		ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if got := r.URL.Query().Get("sort"); got != tt.exp {
+				t.Errorf("Got sort: %q, expected %q", got, tt.exp)
+			}
+			w.Write([]byte("[]"))
+		}))
+
+		fundaClient := NewClient("foobar")
+		fundaClient.BaseURL = ts.URL
+		fundaClient.SortOrder = tt.order
+
+		if _, err := fundaClient.SearchPage("", 1, 25); err != nil {
+			t.Fatalf("Got: %v, expected %v", err, nil)
+		}
+		ts.Close()
+	}
+}
+
+func TestOnRequestOnResponse(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("[]"))
+	}))
+	defer ts.Close()
+
+	var reqURL string
+	var respStatus int
+
+	fundaClient := NewClient("foobar")
+	fundaClient.BaseURL = ts.URL
+	fundaClient.OnRequest = func(r *http.Request) {
+		reqURL = r.URL.String()
+	}
+	fundaClient.OnResponse = func(r *http.Response) {
+		respStatus = r.StatusCode
+	}
+
+	if _, err := fundaClient.Search("/amsterdam/", 1, 25); err != nil {
+		t.Fatalf("Got: %v, expected %v", err, nil)
+	}
+
+	if exp := ts.URL + "/Aanbod/koop/amsterdam/?page=1&pageSize=25"; reqURL != exp {
+		t.Fatalf("Got: %v, expected %v", reqURL, exp)
+	}
+	if respStatus != http.StatusOK {
+		t.Fatalf("Got: %v, expected %v", respStatus, http.StatusOK)
+	}
+}
+
+func TestClientClone(t *testing.T) {
+	fundaClient := NewClient("foobar")
+	fundaClient.ExtraHeaders = http.Header{"X-Foo": []string{"bar"}}
+
+	clone := fundaClient.Clone()
+	clone.ExtraHeaders.Set("X-Foo", "baz")
+	clone.APIKey = "baz"
+
+	if got := fundaClient.ExtraHeaders.Get("X-Foo"); got != "bar" {
+		t.Fatalf("Got: %v, expected %v", got, "bar")
+	}
+	if fundaClient.APIKey != "foobar" {
+		t.Fatalf("Got: %v, expected %v", fundaClient.APIKey, "foobar")
+	}
+	if clone.HTTPClient != fundaClient.HTTPClient {
+		t.Fatal("Expected HTTP client to be shared")
+	}
+}
+
+func TestSearchGzip(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if got := r.Header.Get("Accept-Encoding"); got != "gzip" {
+			t.Errorf("Got Accept-Encoding: %v, expected %v", got, "gzip")
+		}
+
+		body, err := os.ReadFile("test_data/funda_search_response.json")
+		if strings.HasPrefix(r.URL.Path, "/Aanbod/Detail/") {
+			body, err = os.ReadFile("test_data/funda_house_response.json")
+		}
+		if err != nil {
+			t.Error(err)
+			return
+		}
+
+		w.Header().Set("Content-Encoding", "gzip")
+		zw := gzip.NewWriter(w)
+		zw.Write(body)
+		zw.Close()
+	}))
+	defer ts.Close()
+
+	fundaClient := NewClient("foobar")
+	fundaClient.BaseURL = ts.URL
+
+	got, err := fundaClient.Search("", 0, 0)
+	if err != nil {
+		t.Fatalf("Got: %v, expected %v", err, nil)
+	}
+	if len(got) != 1 || got[0].PriceEUR != 400000 {
+		t.Fatalf("Got: %v, expected 1 populated house", got)
+	}
+}
+
+func TestMaxResponseBytes(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.ServeFile(w, r, "test_data/funda_search_response.json")
+	}))
+	defer ts.Close()
+
+	fundaClient := NewClient("foobar")
+	fundaClient.BaseURL = ts.URL
+	fundaClient.MaxResponseBytes = 100
+
+	_, err := fundaClient.Search("", 0, 0)
+	if !errors.Is(err, ErrResponseTooLarge) {
+		t.Fatalf("Got: %v, expected %v", err, ErrResponseTooLarge)
+	}
+	if n := strings.Count(err.Error(), "funda:"); n != 1 {
+		t.Fatalf("Got: %q, expected a single prefix", err)
+	}
+
+	fundaClient.MaxResponseBytes = math.MaxInt64
+	fundaClient.SkipDetails = true
+
+	if _, err := fundaClient.Search("", 0, 0); err != nil {
+		t.Fatalf("Got: %v, expected %v", err, nil)
+	}
+}
+
+func TestSearchPathPrefix(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/Aanbod/nieuwbouw/amsterdam/" {
+			t.Errorf("Unexpected request path: %v", r.URL.Path)
+		}
+		if got := r.Header.Get("Accept-Language"); got != "en-US" {
+			t.Errorf("Got Accept-Language: %v, expected %v", got, "en-US")
+		}
+		w.Write([]byte("[]"))
+	}))
+	defer ts.Close()
+
+	fundaClient := NewClient("foobar")
+	fundaClient.BaseURL = ts.URL
+	fundaClient.SearchPathPrefix = "/Aanbod/nieuwbouw"
+	fundaClient.AcceptLanguage = "en-US"
+
+	if _, err := fundaClient.Search("/amsterdam/", 1, 25); err != nil {
+		t.Fatalf("Got: %v, expected %v", err, nil)
+	}
+}
+
+func TestSearchPage(t *testing.T) {
+	searchResp := searchResponseWithIDs(t, 1)
+	envelope := []byte(`{"Objects":` + string(searchResp) + `,"TotaalAantalObjecten":51,` +
+		`"Paging":{"AantalPaginas":3,"HuidigePagina":2}}`)
+
+	tests := []struct {
+		name string
+		body []byte
+		exp  SearchResult
+	}{
+		{"list", searchResp, SearchResult{Page: 2, PageSize: 1, HasNextPage: true, listings: 1}},
+		{"envelope", envelope, SearchResult{TotalCount: 51, Page: 2, PageSize: 1, HasNextPage: true, listings: 1}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if strings.HasPrefix(r.URL.Path, "/Aanbod/Detail/") {
+					http.ServeFile(w, r, "test_data/funda_house_response.json")
+					return
+				}
+				w.Write(tt.body)
+			}))
+			defer ts.Close()
+
+			fundaClient := NewClient("foobar")
+			fundaClient.BaseURL = ts.URL
+
+			got, err := fundaClient.SearchPage("", 2, 1)
+			if err != nil {
+				t.Fatalf("Got: %v, expected %v", err, nil)
+			}
+			if len(got.Houses) != 1 {
+				t.Fatalf("Got: %v houses, expected %v", len(got.Houses), 1)
+			}
+
+			got.Houses = nil
+			if !reflect.DeepEqual(*got, tt.exp) {
+				t.Fatalf("Got: %+v, expected %+v", *got, tt.exp)
+			}
+		})
+	}
+}
+
+func TestSearchPageFacets(t *testing.T) {
+	body := []byte(`{"Objects":[],"Paging":{"AantalPaginas":1,"HuidigePagina":1},` +
+		`"Facetten":[{"Naam":"Plaats","Opties":[{"Label":"Amsterdam","Aantal":1234},{"Label":"Haarlem","Aantal":56}]}]}`)
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write(body)
+	}))
+	defer ts.Close()
+
+	fundaClient := NewClient("foobar")
+	fundaClient.BaseURL = ts.URL
+
+	got, err := fundaClient.SearchPage("", 1, 25)
+	if err != nil {
+		t.Fatalf("Got: %v, expected %v", err, nil)
+	}
+
+	exp := map[string][]Facet{"Plaats": {{"Amsterdam", 1234}, {"Haarlem", 56}}}
+	if !reflect.DeepEqual(got.Facets, exp) {
+		t.Fatalf("Got: %v, expected %v", got.Facets, exp)
+	}
+}
+
+func TestSearchURL(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasPrefix(r.URL.Path, "/Aanbod/Detail/") {
+			http.ServeFile(w, r, "test_data/funda_house_response.json")
+			return
+		}
+		if r.URL.Path != "/Aanbod/koop/amsterdam/tuin/" || r.URL.Query().Get("sort") != "datum-af" {
+			t.Errorf("Unexpected request URL: %v", r.URL)
+		}
+		if got := r.Header.Get("api_key"); got != "foobar" {
+			t.Errorf("Got api_key: %v, expected %v", got, "foobar")
+		}
+		w.Write(searchResponseWithIDs(t, 1))
+	}))
+	defer ts.Close()
+
+	fundaClient := NewClient("foobar")
+	fundaClient.BaseURL = ts.URL
+
+	got, err := fundaClient.SearchURL(ts.URL + "/Aanbod/koop/amsterdam/tuin/?sort=datum-af&page=3&pageSize=1")
+	if err != nil {
+		t.Fatalf("Got: %v, expected %v", err, nil)
+	}
+	if len(got.Houses) != 1 || got.Page != 3 || got.PageSize != 1 {
+		t.Fatalf("Got: %+v, expected 1 house on page 3", got)
+	}
+}
+
+func TestParseSearchResult(t *testing.T) {
+	f, err := os.Open("test_data/funda_search_response.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+
+	got, err := ParseSearchResult(f)
+	if err != nil {
+		t.Fatalf("Got: %v, expected %v", err, nil)
+	}
+	if len(got.Houses) != 1 {
+		t.Fatalf("Got: %v houses, expected %v", len(got.Houses), 1)
+	}
+	if h := got.Houses[0]; h.ID != 4094475 || h.Address != "Buiksloterbreek 65" || h.Title != h.Address || h.Description != "" {
+		t.Fatalf("Got: %+v, expected house from search result only", h)
+	}
+}
+
+func TestSearchSkipDetails(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasPrefix(r.URL.Path, "/Aanbod/Detail/") {
+			t.Errorf("Unexpected request path: %v", r.URL.Path)
+		}
+		http.ServeFile(w, r, "test_data/funda_search_response.json")
+	}))
+	defer ts.Close()
+
+	fundaClient := NewClient("foobar")
+	fundaClient.BaseURL = ts.URL
+	fundaClient.SkipDetails = true
+
+	got, err := fundaClient.Search("", 0, 0)
+	if err != nil {
+		t.Fatalf("Got: %v, expected %v", err, nil)
+	}
+
+	if len(got) != 1 {
+		t.Fatalf("Got: %v houses, expected %v", len(got), 1)
+	}
+
+	if got[0].Address != "Buiksloterbreek 65" || got[0].PriceEUR != 598011 ||
+		got[0].PriceCondition != PriceConditionKostenKoper {
+		t.Fatalf("Got: %+v, expected house populated from search result", *got[0])
+	}
+}
+
+func TestSearchIncludeAds(t *testing.T) {
+	searchResp := searchResponseWithIDs(t, 1, 2)
+
+	var items []map[string]interface{}
+	if err := json.Unmarshal(searchResp, &items); err != nil {
+		t.Fatal(err)
+	}
+	items[0]["ItemType"] = 2
+	searchResp, _ = json.Marshal(items)
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write(searchResp)
+	}))
+	defer ts.Close()
+
+	fundaClient := NewClient("foobar")
+	fundaClient.BaseURL = ts.URL
+	fundaClient.SkipDetails = true
+
+	got, err := fundaClient.Search("", 0, 0)
+	if err != nil {
+		t.Fatalf("Got: %v, expected %v", err, nil)
+	}
+	if len(got) != 1 || got[0].ID != 2 {
+		t.Fatalf("Got: %v houses, expected only house 2", len(got))
+	}
+
+	fundaClient.IncludeAds = true
+
+	got, err = fundaClient.Search("", 0, 0)
+	if err != nil {
+		t.Fatalf("Got: %v, expected %v", err, nil)
+	}
+	if len(got) != 2 || !got[0].IsAd || got[1].IsAd {
+		t.Fatalf("Got: %v houses, expected ad and regular house", len(got))
+	}
+}
+
+func TestSearchAllFuncStops(t *testing.T) {
+	var pages int
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		pages++
+		page, _ := strconv.Atoi(r.URL.Query().Get("page"))
+		w.Write(searchResponseWithIDs(t, page*10+1, page*10+2))
+	}))
+	defer ts.Close()
+
+	fundaClient := NewClient("foobar")
+	fundaClient.BaseURL = ts.URL
+	fundaClient.SkipDetails = true
+
+	errStop := errors.New("stop")
+	var ids []int
+
+	err := fundaClient.SearchAllFunc(context.Background(), "", 2, func(h *House) error {
+		ids = append(ids, h.ID)
+		if len(ids) == 3 {
+			return errStop
+		}
+		return nil
+	})
+	if err != errStop {
+		t.Fatalf("Got: %v, expected %v", err, errStop)
+	}
+
+	if exp := []int{11, 12, 21}; !reflect.DeepEqual(ids, exp) {
+		t.Fatalf("Got: %v, expected %v", ids, exp)
+	}
+	if pages != 2 {
+		t.Fatalf("Got: %v pages, expected %v", pages, 2)
+	}
+}
+
+func TestSearchMunicipalities(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("page") != "1" {
+			w.Write([]byte("[]"))
+			return
+		}
+		switch r.URL.Path {
+		case "/Aanbod/koop/amsterdam/0-400000/":
+			w.Write(searchResponseWithIDs(t, 1, 2))
+		case "/Aanbod/koop/haarlem/0-400000/":
+			w.Write(searchResponseWithIDs(t, 2, 3))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer ts.Close()
+
+	fundaClient := NewClient("foobar")
+	fundaClient.BaseURL = ts.URL
+	fundaClient.SkipDetails = true
+
+	q := SearchQuery{MaxPrice: 400000}
+	got, err := fundaClient.SearchMunicipalities([]string{"Amsterdam", "Haarlem", "Utrecht"}, q, 25)
+	if !errors.Is(err, ErrNotFound) || !strings.Contains(err.Error(), "Utrecht") {
+		t.Fatalf("Got: %v, expected %v for Utrecht", err, ErrNotFound)
+	}
+	if n := strings.Count(err.Error(), "funda:"); n != 1 {
+		t.Fatalf("Got: %q, expected a single prefix", err)
+	}
+
+	var ids []int
+	for _, h := range got {
+		ids = append(ids, h.ID)
+	}
+	if exp := []int{1, 2, 3}; !reflect.DeepEqual(ids, exp) {
+		t.Fatalf("Got: %v, expected %v", ids, exp)
+	}
+}
+
+func TestSearchN(t *testing.T) {
+	var details int32
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasPrefix(r.URL.Path, "/Aanbod/Detail/") {
+			atomic.AddInt32(&details, 1)
+			http.ServeFile(w, r, "test_data/funda_house_response.json")
+			return
+		}
+		page, _ := strconv.Atoi(r.URL.Query().Get("page"))
+		w.Write(searchResponseWithIDs(t, page*10+1, page*10+2))
+	}))
+	defer ts.Close()
+
+	fundaClient := NewClient("foobar")
+	fundaClient.BaseURL = ts.URL
+
+	got, err := fundaClient.SearchN("", 3)
+	if err != nil {
+		t.Fatalf("Got: %v, expected %v", err, nil)
+	}
+
+	var ids []int
+	for _, h := range got {
+		ids = append(ids, h.ID)
+	}
+	if exp := []int{11, 12, 21}; !reflect.DeepEqual(ids, exp) {
+		t.Fatalf("Got: %v, expected %v", ids, exp)
+	}
+	if details != 3 {
+		t.Fatalf("Got: %v detail requests, expected %v", details, 3)
+	}
+}
+
+func TestSearchNFailedDetails(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/Aanbod/Detail/Koop/12" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		if strings.HasPrefix(r.URL.Path, "/Aanbod/Detail/") {
+			http.ServeFile(w, r, "test_data/funda_house_response.json")
+			return
+		}
+		page, _ := strconv.Atoi(r.URL.Query().Get("page"))
+		w.Write(searchResponseWithIDs(t, page*10+1, page*10+2, page*10+3))
+	}))
+	defer ts.Close()
+
+	fundaClient := NewClient("foobar")
+	fundaClient.BaseURL = ts.URL
+
+	got, err := fundaClient.SearchN("", 2)
+	if err != nil {
+		t.Fatalf("Got: %v, expected %v", err, nil)
+	}
+
+	var ids []int
+	for _, h := range got {
+		ids = append(ids, h.ID)
+	}
+	if exp := []int{11, 13}; !reflect.DeepEqual(ids, exp) {
+		t.Fatalf("Got: %v, expected %v", ids, exp)
+	}
+}
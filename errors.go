@@ -0,0 +1,46 @@
+package funda
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+)
+
+// ErrMissingAPIKey is returned when the Client has no API key configured.
+var ErrMissingAPIKey = errors.New("funda: missing API key")
+
+// ErrResponseTooLarge is returned (wrapped) when a response body exceeds the
+// MaxResponseBytes of the Client. Like StatusError, it leaves the "funda:"
+// prefix to the error wrapping it.
+var ErrResponseTooLarge = errors.New("response too large")
+
+// Errors returned (wrapped) for common unsuccessful HTTP responses.
+var (
+	ErrUnauthorized = errors.New("funda: unauthorized")
+	ErrNotFound     = errors.New("funda: not found")
+	ErrRateLimited  = errors.New("funda: rate limited")
+)
+
+// StatusError is returned when the Funda API responds with an unexpected HTTP
+// status code. It unwraps to ErrUnauthorized, ErrNotFound or ErrRateLimited
+// when applicable, to be used with errors.Is.
+type StatusError struct {
+	StatusCode int
+}
+
+func (e *StatusError) Error() string {
+	return fmt.Sprintf("unexpected HTTP response code (%d) received", e.StatusCode)
+}
+
+// Unwrap returns the sentinel error for the status code, if any.
+func (e *StatusError) Unwrap() error {
+	switch e.StatusCode {
+	case http.StatusUnauthorized, http.StatusForbidden:
+		return ErrUnauthorized
+	case http.StatusNotFound:
+		return ErrNotFound
+	case http.StatusTooManyRequests:
+		return ErrRateLimited
+	}
+	return nil
+}
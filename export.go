@@ -0,0 +1,53 @@
+package funda
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"io"
+	"strconv"
+)
+
+var csvHeader = []string{"id", "address", "price_eur", "living_area_m2", "rooms", "url"}
+
+// WriteCSV writes houses as CSV to w: a header row followed by one row per
+// house.
+func WriteCSV(w io.Writer, houses []*House) error {
+	cw := csv.NewWriter(w)
+
+	if err := cw.Write(csvHeader); err != nil {
+		return err
+	}
+
+	for _, h := range houses {
+		record := []string{
+			strconv.Itoa(h.ID),
+			h.Address,
+			strconv.Itoa(h.PriceEUR),
+			strconv.Itoa(h.LivingAreaM2),
+			strconv.Itoa(h.TotalRooms),
+			h.URL.String(),
+		}
+		if err := cw.Write(record); err != nil {
+			return err
+		}
+	}
+
+	cw.Flush()
+
+	return cw.Error()
+}
+
+// WriteJSONL writes houses as newline-delimited JSON to w, one object per
+// house. Every house is written to w as soon as it's encoded, so it can also
+// be called per house, e.g. from the callback of SearchAllFunc.
+func WriteJSONL(w io.Writer, houses []*House) error {
+	enc := json.NewEncoder(w)
+
+	for _, h := range houses {
+		if err := enc.Encode(h); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
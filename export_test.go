@@ -0,0 +1,75 @@
+package funda
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestWriteCSV(t *testing.T) {
+	houses := []*House{
+		{
+			ID:           4094475,
+			Address:      "Buiksloterbreek 65",
+			PriceEUR:     400000,
+			LivingAreaM2: 68,
+			TotalRooms:   3,
+			URL:          parseURL("https://www.funda.nl/40443683"),
+		},
+	}
+
+	var buf bytes.Buffer
+	if err := WriteCSV(&buf, houses); err != nil {
+		t.Fatal(err)
+	}
+
+	exp := "id,address,price_eur,living_area_m2,rooms,url\n" +
+		"4094475,Buiksloterbreek 65,400000,68,3,https://www.funda.nl/40443683\n"
+	if got := buf.String(); got != exp {
+		t.Fatalf("Got: %q, expected %q", got, exp)
+	}
+
+	buf.Reset()
+	if err := WriteCSV(&buf, nil); err != nil {
+		t.Fatal(err)
+	}
+
+	if exp := "id,address,price_eur,living_area_m2,rooms,url\n"; buf.String() != exp {
+		t.Fatalf("Got: %q, expected %q", buf.String(), exp)
+	}
+}
+
+func TestWriteJSONL(t *testing.T) {
+	houses := []*House{
+		{ID: 1, URL: parseURL("https://www.funda.nl/1")},
+		{ID: 2, URL: parseURL("https://www.funda.nl/2")},
+	}
+
+	var buf bytes.Buffer
+	if err := WriteJSONL(&buf, houses); err != nil {
+		t.Fatal(err)
+	}
+
+	lines := strings.Split(strings.TrimSuffix(buf.String(), "\n"), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("Got: %v lines, expected %v", len(lines), 2)
+	}
+	for i, line := range lines {
+		var h House
+		if err := json.Unmarshal([]byte(line), &h); err != nil {
+			t.Fatal(err)
+		}
+		if h.ID != houses[i].ID || h.URL != houses[i].URL {
+			t.Fatalf("Got: %+v, expected %+v", h, *houses[i])
+		}
+	}
+
+	buf.Reset()
+	if err := WriteJSONL(&buf, nil); err != nil {
+		t.Fatal(err)
+	}
+	if buf.Len() != 0 {
+		t.Fatalf("Got: %q, expected empty output", buf.String())
+	}
+}
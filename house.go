@@ -1,14 +1,374 @@
 package funda
 
-import "net/url"
+import (
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
 
 // House represents a house or real estate object on Funda.
 type House struct {
-	ID          int
-	Address     string
-	Price       string
-	URL         url.URL
-	ImageURL    url.URL
-	SurfaceArea string
-	Rooms       string
+	ID                 int
+	ListingType        ListingType
+	Status             ListingStatus
+	IsAd               bool
+	Address            string
+	Title              string
+	SummaryLines       []string
+	Description        string
+	PostalCode         string
+	City               string
+	Municipality       string
+	Neighborhood       string
+	PriceRaw           string
+	PriceEUR           int
+	PriceMinEUR        int
+	PriceMaxEUR        int
+	PriceCondition     PriceCondition
+	PricePeriod        PricePeriod
+	PriceOnRequest     bool
+	PreviousPriceEUR   int
+	PriceReduced       bool
+	ServiceCostsEUR    int
+	URL                url.URL
+	FundaSlug          string
+	ImageURL           url.URL
+	ImageURLs          []url.URL
+	SurfaceAreaRaw     string
+	LivingAreaM2       int
+	PlotAreaRaw        string
+	PlotAreaM2         int
+	VolumeM3           int
+	Areas              map[string]string
+	Rooms              string
+	TotalRooms         int
+	Bedrooms           int
+	Bathrooms          int
+	SeparateToilets    int
+	RoomDetails        []RoomDetail
+	KitchenType        string
+	KitchenAppliances  []string
+	Floor              int
+	NumberOfFloors     int
+	HasAttic           bool
+	HasBasement        bool
+	AtticAreaM2        int
+	BasementAreaM2     int
+	Latitude           float64
+	Longitude          float64
+	Location           []string
+	Agent              Agent
+	Garden             Garden
+	Parking            string
+	GarageType         string
+	GarageCapacity     int
+	ExternalStorage    string
+	BalconyRaw         string
+	Balcony            bool
+	RoofTerrace        bool
+	Cadastral          Cadastral
+	Ownership          string
+	LeaseholdUntil     time.Time
+	LeaseholdAnnualEUR int
+	PropertyType       string
+	PropertyKind       PropertyKind
+	ConstructionType   string
+	EnergyLabel        string
+	Heating            []string
+	Insulation         []string
+	InsulationTypes    []InsulationType
+	SolarPanels        bool
+	HeatPump           bool
+	SpecialFeatures    []string
+	SpecialConditions  []string
+	Facilities         []string
+	HasElevator        bool
+	YearBuilt          int
+	YearBuiltApprox    bool
+	HasVvE             bool
+	VvEMonthlyCostEUR  int
+	VvEDetails         map[string]string
+	Attributes         map[string]string
+	Acceptance         string
+	AcceptanceDate     time.Time
+	ListedSince        time.Time
+	ListedSinceApprox  bool
+}
+
+// FloorUnknown is the Floor of houses for which the floor is not listed, e.g.
+// because they are not apartments.
+const FloorUnknown = -1
+
+// newHouse returns a House with the given ID, and defaults for fields whose
+// zero value is meaningful.
+func newHouse(id int) *House {
+	return &House{
+		ID:    id,
+		Floor: FloorUnknown,
+	}
+}
+
+// copyPrice sets all price values of h to those of other, so they stay
+// consistent with each other.
+func (h *House) copyPrice(other *House) {
+	h.PriceRaw = other.PriceRaw
+	h.PriceEUR = other.PriceEUR
+	h.PriceMinEUR = other.PriceMinEUR
+	h.PriceMaxEUR = other.PriceMaxEUR
+	h.PriceCondition = other.PriceCondition
+	h.PricePeriod = other.PricePeriod
+	h.PriceOnRequest = other.PriceOnRequest
+	h.PreviousPriceEUR = other.PreviousPriceEUR
+	h.PriceReduced = other.PriceReduced
+}
+
+// HasCoordinates returns whether the geographic coordinates of the house are
+// known.
+func (h *House) HasCoordinates() bool {
+	return h.Latitude != 0 || h.Longitude != 0
+}
+
+// String returns a concise description of the house, e.g.
+// "Buiksloterbreek 65 — € 400.000 — 68 m² — 3 kamers (#4094475)". Unknown
+// values are omitted.
+func (h *House) String() string {
+	var parts []string
+
+	if h.Address != "" {
+		parts = append(parts, h.Address)
+	}
+
+	switch {
+	case h.PriceOnRequest:
+		parts = append(parts, "Prijs op aanvraag")
+	case h.PriceEUR > 0:
+		price := "€ " + formatThousands(h.PriceEUR)
+		if h.PricePeriod == PricePeriodMonth {
+			price += " /mnd"
+		}
+		parts = append(parts, price)
+	}
+
+	if h.LivingAreaM2 > 0 {
+		parts = append(parts, strconv.Itoa(h.LivingAreaM2)+" m²")
+	}
+
+	switch {
+	case h.TotalRooms == 1:
+		parts = append(parts, "1 kamer")
+	case h.TotalRooms > 1:
+		parts = append(parts, strconv.Itoa(h.TotalRooms)+" kamers")
+	}
+
+	id := fmt.Sprintf("(#%d)", h.ID)
+	if len(parts) == 0 {
+		return id
+	}
+
+	return strings.Join(parts, " — ") + " " + id
+}
+
+// formatThousands formats n with "." as thousands separator, e.g. "400.000".
+func formatThousands(n int) string {
+	if n < 0 {
+		return "-" + formatThousands(-n)
+	}
+
+	s := strconv.Itoa(n)
+	for i := len(s) - 3; i > 0; i -= 3 {
+		s = s[:i] + "." + s[i:]
+	}
+
+	return s
+}
+
+// PricePerM2 returns the price in euros per square meter of living area, or 0
+// when the living area is unknown.
+func (h *House) PricePerM2() float64 {
+	if h.LivingAreaM2 == 0 {
+		return 0
+	}
+	return float64(h.PriceEUR) / float64(h.LivingAreaM2)
+}
+
+// DaysOnMarket returns the number of whole days since the house was listed,
+// or -1 when the listing date is unknown. It counts up to the wall-clock time,
+// not Client.Now; use DaysOnMarketAt with the clock of the Client for that.
+func (h *House) DaysOnMarket() int {
+	return h.DaysOnMarketAt(time.Now())
+}
+
+// DaysOnMarketAt is like DaysOnMarket, counting the days up to t instead of
+// now, e.g. to use the same clock as Client.Now.
+func (h *House) DaysOnMarketAt(t time.Time) int {
+	if h.ListedSince.IsZero() {
+		return -1
+	}
+
+	// Count calendar days, so a DST change doesn't lose a day.
+	y, m, d := t.In(amsterdam).Date()
+	today := time.Date(y, m, d, 0, 0, 0, 0, time.UTC)
+	y, m, d = h.ListedSince.In(amsterdam).Date()
+	listed := time.Date(y, m, d, 0, 0, 0, 0, time.UTC)
+
+	return int(today.Sub(listed).Hours() / 24)
+}
+
+// HasGarden returns whether the house has a garden.
+func (h *House) HasGarden() bool {
+	return h.Garden != Garden{}
+}
+
+// isLeasehold returns whether the land is leased (erfpacht), as opposed to
+// freehold ("Volle eigendom").
+func (h *House) isLeasehold() bool {
+	return strings.Contains(strings.ToLower(h.Ownership), "erfpacht")
+}
+
+// IsNewConstruction returns whether the house is a new-build (nieuwbouw)
+// project.
+func (h *House) IsNewConstruction() bool {
+	return strings.EqualFold(h.ConstructionType, "Nieuwbouw")
+}
+
+// HasParking returns whether the house has any parking.
+func (h *House) HasParking() bool {
+	return h.Parking != "" && !strings.EqualFold(h.Parking, "Geen")
+}
+
+// IsValidEnergyLabel returns whether s is a valid energy label class, ranging
+// from "A++++" to "G".
+func IsValidEnergyLabel(s string) bool {
+	switch s {
+	case "A++++", "A+++", "A++", "A+", "A", "B", "C", "D", "E", "F", "G":
+		return true
+	}
+	return false
+}
+
+// PriceCondition indicates which transfer costs are included in the asking
+// price.
+type PriceCondition int
+
+// Price conditions as listed after the asking price.
+const (
+	PriceConditionUnknown     PriceCondition = iota
+	PriceConditionKostenKoper                // "k.k.", costs are paid by the buyer.
+	PriceConditionVrijOpNaam                 // "v.o.n.", costs are included.
+)
+
+// PricePeriod indicates the period a price applies to. Asking prices for sale
+// have no period; rental prices are listed per month.
+type PricePeriod int
+
+// Price periods.
+const (
+	PricePeriodNone PricePeriod = iota
+	PricePeriodMonth
+)
+
+// PropertyKind defines the main kind of a property.
+type PropertyKind int
+
+// Property kinds.
+const (
+	PropertyKindUnknown   PropertyKind = iota
+	PropertyKindHouse                  // "Soort woonhuis"
+	PropertyKindApartment              // "Soort appartement"
+	PropertyKindOther                  // "Soort object", e.g. a parking space.
+)
+
+func propertyKindFromLabel(label string) PropertyKind {
+	switch label {
+	case "Soort woonhuis":
+		return PropertyKindHouse
+	case "Soort appartement":
+		return PropertyKindApartment
+	case "Soort object":
+		return PropertyKindOther
+	}
+	return PropertyKindUnknown
+}
+
+// InsulationType defines a kind of insulation, normalized from the Dutch
+// terms used in listings.
+type InsulationType int
+
+// Insulation types. Terms that aren't recognized map to InsulationOther.
+const (
+	InsulationOther            InsulationType = iota
+	InsulationRoof                            // "Dakisolatie"
+	InsulationWall                            // "Muurisolatie", "Spouwmuurisolatie"
+	InsulationFloor                           // "Vloerisolatie"
+	InsulationDoubleGlazing                   // "Dubbel glas", "HR-glas"
+	InsulationTripleGlazing                   // "Driedubbel glas"
+	InsulationSecondaryGlazing                // "Voorzetramen"
+	InsulationFull                            // "Volledig geïsoleerd"
+)
+
+// ListingStatus defines the sale status of a listing.
+type ListingStatus int
+
+// Listing statuses. Houses without a status marker are available.
+const (
+	StatusAvailable     ListingStatus = iota
+	StatusUnderBid                    // "Onder bod"
+	StatusSoldSubjectTo               // "Verkocht onder voorbehoud"
+	StatusSold                        // "Verkocht"
+)
+
+// Agent represents the real estate agent (makelaar) of a listing.
+type Agent struct {
+	Name  string
+	Phone string
+	URL   url.URL
+}
+
+func (a *Agent) parseMakelaar(m makelaar) error {
+	a.Name = m.Name
+
+	if m.Link != "" {
+		agentURL, err := url.Parse(m.Link)
+		if err != nil {
+			return err
+		}
+		a.URL = *agentURL
+	}
+
+	for _, b := range m.Buttons {
+		if strings.HasPrefix(b.Action, "tel:") {
+			a.Phone = strings.TrimPrefix(b.Action, "tel:")
+			break
+		}
+	}
+
+	return nil
+}
+
+// Garden represents the garden (tuin) of a house.
+type Garden struct {
+	// Type lists the kinds of garden, e.g. "Achtertuin en voortuin".
+	Type string
+	// Orientation is the compass direction the garden faces, e.g. "zuiden"
+	// or "noordwesten".
+	Orientation string
+	// AreaM2 is the total area of all gardens in square meters.
+	AreaM2 int
+}
+
+// Cadastral represents the cadastral (kadaster) information of a property.
+// When a property consists of multiple parcels, Designation holds the first
+// parcel and AreaM2 the total area of all parcels.
+type Cadastral struct {
+	Designation string
+	AreaM2      int
+}
+
+// RoomDetail represents a room in the room breakdown of a listing, e.g.
+// "Woonkamer 28 m²".
+type RoomDetail struct {
+	Name   string
+	AreaM2 int
 }
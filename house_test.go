@@ -0,0 +1,479 @@
+package funda
+
+import (
+	"os"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestPricePerM2(t *testing.T) {
+	tests := []struct {
+		house House
+		exp   float64
+	}{
+		{House{PriceEUR: 400000, LivingAreaM2: 68}, 400000.0 / 68},
+		{House{PriceEUR: 400000}, 0},
+		{House{}, 0},
+	}
+
+	for _, tt := range tests {
+		if got := tt.house.PricePerM2(); got != tt.exp {
+			t.Errorf("Got: %v, expected %v", got, tt.exp)
+		}
+	}
+}
+
+func TestParseDetailsSkipsInvalidLists(t *testing.T) {
+	body := `[{"Section":12,"List":[
+		{"Title":"Overdracht","List":[{"Label":"Vraagprijs","Value":"€ 400.000 k.k."},{"Label":1}]},
+		"invalid",
+		{"Label":"Bouwjaar","Value":"1906"}
+	]}]`
+
+	var h House
+	if err := h.parseDetailsFromAPIResponse(strings.NewReader(body), nopLogger{}, time.Now()); err != nil {
+		t.Fatalf("Got: %v, expected %v", err, nil)
+	}
+
+	if h.PriceEUR != 400000 || h.YearBuilt != 1906 {
+		t.Fatalf("Got: %+v, expected price and year built to be parsed", h)
+	}
+}
+
+func TestParseCadastralParcels(t *testing.T) {
+	body := `[{"Section":12,"List":[{"Title":"Kadastrale gegevens","List":[
+		{"Title":"Amsterdam Q 8224","List":[{"Label":"Oppervlakte","Value":"120 m²"}]},
+		{"Title":"Amsterdam Q 8225","List":[{"Label":"Oppervlakte","Value":"1.030 m²"}]}
+	]}]}]`
+
+	var h House
+	if err := h.parseDetailsFromAPIResponse(strings.NewReader(body), nopLogger{}, time.Now()); err != nil {
+		t.Fatalf("Got: %v, expected %v", err, nil)
+	}
+
+	if exp := (Cadastral{Designation: "Amsterdam Q 8224", AreaM2: 1150}); h.Cadastral != exp {
+		t.Fatalf("Got: %+v, expected %+v", h.Cadastral, exp)
+	}
+}
+
+func TestHouseString(t *testing.T) {
+	tests := []struct {
+		house *House
+		exp   string
+	}{
+		{
+			&House{ID: 4094475, Address: "Buiksloterbreek 65", PriceEUR: 400000, LivingAreaM2: 68, TotalRooms: 3},
+			"Buiksloterbreek 65 — € 400.000 — 68 m² — 3 kamers (#4094475)",
+		},
+		{
+			&House{ID: 1, Address: "Damrak 1", PriceEUR: 1500, PricePeriod: PricePeriodMonth, TotalRooms: 1},
+			"Damrak 1 — € 1.500 /mnd — 1 kamer (#1)",
+		},
+		{&House{ID: 2, PriceOnRequest: true}, "Prijs op aanvraag (#2)"},
+		{&House{ID: 3}, "(#3)"},
+	}
+
+	for _, tt := range tests {
+		if got := tt.house.String(); got != tt.exp {
+			t.Errorf("Got: %q, expected %q", got, tt.exp)
+		}
+	}
+}
+
+func TestParseDetailsPrefersCanonicalURL(t *testing.T) {
+	tests := []struct {
+		url  string
+		slug string
+	}{
+		{"https://www.funda.nl/koop/amsterdam/appartement-40443683-de-clercqstraat-20-1/", "40443683"},
+		{"https://www.funda.nl/detail/koop/amsterdam/appartement-de-clercqstraat-20-1/43650952/", "43650952"},
+	}
+
+	for _, tt := range tests {
+		body := `[
+			{"Section":9,"URL":"https://mobile.funda.io/api/v1/Aanbod/Detail/Koop/4094475"},
+			{"Section":9,"URL":"` + tt.url + `"},
+			{"Section":9,"URL":"https://www.funda.nl/40443684"}
+		]`
+
+		var h House
+		if err := h.parseDetailsFromAPIResponse(strings.NewReader(body), nopLogger{}, time.Now()); err != nil {
+			t.Fatalf("Got: %v, expected %v", err, nil)
+		}
+
+		if h.URL.String() != tt.url {
+			t.Fatalf("Got: %v, expected %v", h.URL.String(), tt.url)
+		}
+		if h.FundaSlug != tt.slug {
+			t.Fatalf("Got: %v, expected %v", h.FundaSlug, tt.slug)
+		}
+	}
+}
+
+func TestHasParking(t *testing.T) {
+	tests := []struct {
+		parking string
+		exp     bool
+	}{
+		{"", false},
+		{"Geen", false},
+		{"Openbaar parkeren en parkeervergunningen", true},
+	}
+
+	for _, tt := range tests {
+		h := House{Parking: tt.parking}
+		if got := h.HasParking(); got != tt.exp {
+			t.Errorf("HasParking() with %q = %v, expected %v", tt.parking, got, tt.exp)
+		}
+	}
+}
+
+func TestParseDetailsSpecialFeatures(t *testing.T) {
+	body := `[{"Section":12,"List":[
+		{"Title":"Specifiek","List":[
+			{"Label":"Bijzonderheden","Value":"Beschermd stadsgezicht, Rijksmonument"},
+			{"Label":"Toegankelijkheid","Value":" Toegankelijk voor ouderen "}
+		]}
+	]}]`
+
+	var h House
+	if err := h.parseDetailsFromAPIResponse(strings.NewReader(body), nopLogger{}, time.Now()); err != nil {
+		t.Fatalf("Got: %v, expected %v", err, nil)
+	}
+
+	exp := []string{"Beschermd stadsgezicht", "Rijksmonument", "Toegankelijk voor ouderen"}
+	if !reflect.DeepEqual(h.SpecialFeatures, exp) {
+		t.Fatalf("Got: %q, expected %q", h.SpecialFeatures, exp)
+	}
+}
+
+func TestParseDetailsBalcony(t *testing.T) {
+	tests := []struct {
+		value       string
+		balcony     bool
+		roofTerrace bool
+	}{
+		{"Balkon aanwezig", true, false},
+		{"Dakterras aanwezig", false, true},
+		{"Balkon en dakterras aanwezig", true, true},
+	}
+
+	for _, tt := range tests {
+		body := `[{"Section":12,"List":[{"Label":"Balkon/dakterras","Value":"` + tt.value + `"}]}]`
+
+		var h House
+		if err := h.parseDetailsFromAPIResponse(strings.NewReader(body), nopLogger{}, time.Now()); err != nil {
+			t.Fatalf("Got: %v, expected %v", err, nil)
+		}
+		if h.BalconyRaw != tt.value || h.Balcony != tt.balcony || h.RoofTerrace != tt.roofTerrace {
+			t.Errorf("%q: got %q, %v, %v; expected %v, %v", tt.value, h.BalconyRaw, h.Balcony, h.RoofTerrace, tt.balcony, tt.roofTerrace)
+		}
+	}
+}
+
+func TestParseHouseDetails(t *testing.T) {
+	f, err := os.Open("test_data/funda_house_response.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+
+	h, err := ParseHouseDetails(f)
+	if err != nil {
+		t.Fatalf("Got: %v, expected %v", err, nil)
+	}
+	if h.PriceEUR != 400000 || h.Floor != 1 || h.FundaSlug != "40443683" {
+		t.Fatalf("Got: %+v, expected populated house", h)
+	}
+
+	if _, err := ParseHouseDetails(strings.NewReader("{")); err == nil {
+		t.Fatal("Expected error for invalid body")
+	}
+}
+
+func TestClientParseHouseDetails(t *testing.T) {
+	body := `[{"Section":12,"List":[{"Label":"Aangeboden sinds","Value":"3 weken"}]}]`
+
+	fundaClient := NewClient("foobar")
+	fundaClient.Now = func() time.Time {
+		return time.Date(2024, time.March, 20, 15, 0, 0, 0, amsterdam)
+	}
+
+	h, err := fundaClient.ParseHouseDetails(strings.NewReader(body))
+	if err != nil {
+		t.Fatalf("Got: %v, expected %v", err, nil)
+	}
+	if exp := time.Date(2024, time.February, 28, 0, 0, 0, 0, amsterdam); !h.ListedSince.Equal(exp) || !h.ListedSinceApprox {
+		t.Fatalf("Got: %v, %v; expected %v, %v", h.ListedSince, h.ListedSinceApprox, exp, true)
+	}
+	if got := h.DaysOnMarketAt(fundaClient.Now()); got != 21 {
+		t.Fatalf("Got: %v, expected %v", got, 21)
+	}
+}
+
+func TestParseDetailsRoomDetails(t *testing.T) {
+	body := `[{"Section":12,"List":[
+		{"Title":"Kamerindeling","List":[
+			{"Label":"Woonkamer","Value":"28 m²"},
+			{"Value":"Slaapkamer 12 m²"}
+		]}
+	]}]`
+
+	var h House
+	if err := h.parseDetailsFromAPIResponse(strings.NewReader(body), nopLogger{}, time.Now()); err != nil {
+		t.Fatalf("Got: %v, expected %v", err, nil)
+	}
+
+	exp := []RoomDetail{{Name: "Woonkamer", AreaM2: 28}, {Name: "Slaapkamer", AreaM2: 12}}
+	if !reflect.DeepEqual(h.RoomDetails, exp) {
+		t.Fatalf("Got: %v, expected %v", h.RoomDetails, exp)
+	}
+}
+
+func TestParseDetailsAtticBasementArea(t *testing.T) {
+	body := `[{"Section":12,"List":[
+		{"Title":"Gebruiksoppervlakten","List":[
+			{"Label":"Wonen (= woonoppervlakte)","Value":"120 m²"},
+			{"Label":"Zolder","Value":"14 m²"},
+			{"Label":"Kelder","Value":"9 m²"}
+		]},
+		{"Label":"Aantal woonlagen","Value":"3 woonlagen"}
+	]}]`
+
+	var h House
+	if err := h.parseDetailsFromAPIResponse(strings.NewReader(body), nopLogger{}, time.Now()); err != nil {
+		t.Fatalf("Got: %v, expected %v", err, nil)
+	}
+
+	if h.AtticAreaM2 != 14 || h.BasementAreaM2 != 9 {
+		t.Fatalf("Got: %v, %v; expected %v, %v", h.AtticAreaM2, h.BasementAreaM2, 14, 9)
+	}
+	if h.HasAttic || h.HasBasement {
+		t.Fatal("Expected HasAttic and HasBasement to only depend on the floors")
+	}
+}
+
+func TestParseDetailsPlotArea(t *testing.T) {
+	for _, label := range []string{"Perceel", "Perceeloppervlakte"} {
+		var h House
+		body := `[{"Section":12,"List":[{"Label":"` + label + `","Value":"1.250 m²"}]}]`
+		if err := h.parseDetailsFromAPIResponse(strings.NewReader(body), nopLogger{}, time.Now()); err != nil {
+			t.Fatalf("Got: %v, expected %v", err, nil)
+		}
+		if h.PlotAreaM2 != 1250 || h.PlotAreaRaw != "1.250 m²" {
+			t.Errorf("%s: got %v, %q; expected %v, %q", label, h.PlotAreaM2, h.PlotAreaRaw, 1250, "1.250 m²")
+		}
+	}
+}
+
+func TestParseDetailsElevator(t *testing.T) {
+	tests := []struct {
+		body string
+		exp  bool
+	}{
+		{`{"Label":"Voorzieningen","Value":"Lift, mechanische ventilatie en TV kabel"}`, true},
+		{`{"Label":"Lift","Value":"Ja"}`, true},
+		{`{"Label":"Lift","Value":"Nee"}`, false},
+		{`{"Label":"Voorzieningen","Value":"Dakraam"}`, false},
+	}
+
+	for _, tt := range tests {
+		var h House
+		body := `[{"Section":12,"List":[` + tt.body + `]}]`
+		if err := h.parseDetailsFromAPIResponse(strings.NewReader(body), nopLogger{}, time.Now()); err != nil {
+			t.Fatalf("Got: %v, expected %v", err, nil)
+		}
+		if h.HasElevator != tt.exp {
+			t.Errorf("%s: got %v, expected %v", tt.body, h.HasElevator, tt.exp)
+		}
+	}
+}
+
+func TestParseDetailsEnergyFeatures(t *testing.T) {
+	body := `[{"Section":12,"List":[
+		{"Title":"Energie","List":[
+			{"Label":"Verwarming","Value":"Warmtepomp en vloerverwarming geheel"},
+			{"Label":"Warm water","Value":"Elektrische boiler"}
+		]},
+		{"Title":"Indeling","List":[
+			{"Label":"Voorzieningen","Value":"Mechanische ventilatie en zonnepanelen"}
+		]}
+	]}]`
+
+	var h House
+	if err := h.parseDetailsFromAPIResponse(strings.NewReader(body), nopLogger{}, time.Now()); err != nil {
+		t.Fatalf("Got: %v, expected %v", err, nil)
+	}
+
+	if !h.SolarPanels || !h.HeatPump {
+		t.Fatalf("Got: %v, %v; expected solar panels and heat pump", h.SolarPanels, h.HeatPump)
+	}
+}
+
+func TestParseSearchInfoPreviousPrice(t *testing.T) {
+	infos := []info{
+		{Line: []houseResponseItemList{{Text: "Buiksloterbreek 65"}}},
+		{Line: []houseResponseItemList{
+			{Text: "€ 650.000", Css: "text-decoration: line-through;"},
+			{Text: "€ 598.011"},
+			{Text: "k.k."},
+			{Text: "Onder bod"},
+		}},
+	}
+
+	var h House
+	h.parseSearchInfo(infos)
+
+	if h.PriceEUR != 598011 || h.PreviousPriceEUR != 650000 || !h.PriceReduced {
+		t.Fatalf("Got: %v, %v, %v; expected %v, %v, %v", h.PriceEUR, h.PreviousPriceEUR, h.PriceReduced, 598011, 650000, true)
+	}
+	if h.PriceCondition != PriceConditionKostenKoper || h.Status != StatusUnderBid {
+		t.Fatalf("Got: %v, %v; expected %v, %v", h.PriceCondition, h.Status, PriceConditionKostenKoper, StatusUnderBid)
+	}
+	if exp := "€ 650.000 € 598.011 k.k. Onder bod"; h.SummaryLines[1] != exp {
+		t.Fatalf("Got: %q, expected %q", h.SummaryLines[1], exp)
+	}
+}
+
+func TestIsNewConstruction(t *testing.T) {
+	if h := (House{ConstructionType: "Nieuwbouw"}); !h.IsNewConstruction() {
+		t.Fatal("Expected new construction")
+	}
+	if h := (House{ConstructionType: "Bestaande bouw"}); h.IsNewConstruction() {
+		t.Fatal("Expected existing construction")
+	}
+}
+
+func TestParseDetailsRent(t *testing.T) {
+	tests := []struct {
+		body         string
+		price        int
+		serviceCosts int
+		onRequest    bool
+	}{
+		{`{"Label":"Huurprijs","Value":"€ 1.750 /mnd"},{"Label":"Servicekosten","Value":"€ 85 /mnd"}`, 1750, 85, false},
+		{`{"Label":"Huurprijs","Value":"Huurprijs op aanvraag"}`, 0, 0, true},
+	}
+
+	for _, tt := range tests {
+		var h House
+		body := `[{"Section":12,"List":[` + tt.body + `]}]`
+		if err := h.parseDetailsFromAPIResponse(strings.NewReader(body), nopLogger{}, time.Now()); err != nil {
+			t.Fatalf("Got: %v, expected %v", err, nil)
+		}
+		if h.PriceEUR != tt.price || h.ServiceCostsEUR != tt.serviceCosts ||
+			h.PriceOnRequest != tt.onRequest || h.PricePeriod != PricePeriodMonth {
+			t.Errorf("%s: got %v, %v, %v, %v", tt.body, h.PriceEUR, h.ServiceCostsEUR, h.PriceOnRequest, h.PricePeriod)
+		}
+	}
+}
+
+func TestParseDetailsGarage(t *testing.T) {
+	body := `[{"Section":12,"List":[
+		{"Title":"Energie","List":[
+			{"Label":"Capaciteit","Value":"4 kW"}
+		]},
+		{"Title":"Garage","List":[
+			{"Label":"Soort garage","Value":"Inpandige garage"},
+			{"Label":"Capaciteit","Value":"Plaats voor 2 auto's"}
+		]},
+		{"Label":"Capaciteit","Value":"300 liter"}
+	]}]`
+
+	var h House
+	if err := h.parseDetailsFromAPIResponse(strings.NewReader(body), nopLogger{}, time.Now()); err != nil {
+		t.Fatalf("Got: %v, expected %v", err, nil)
+	}
+
+	if h.GarageType != "Inpandige garage" || h.GarageCapacity != 2 {
+		t.Fatalf("Got: %q, %v; expected %q, %v", h.GarageType, h.GarageCapacity, "Inpandige garage", 2)
+	}
+}
+
+func TestDaysOnMarket(t *testing.T) {
+	now := time.Date(2024, time.March, 31, 12, 0, 0, 0, amsterdam)
+
+	tests := []struct {
+		listedSince time.Time
+		exp         int
+	}{
+		{time.Time{}, -1},
+		{time.Date(2024, time.March, 31, 0, 0, 0, 0, amsterdam), 0},
+		// Spans the change to summer time on March 31.
+		{time.Date(2024, time.March, 1, 0, 0, 0, 0, amsterdam), 30},
+	}
+
+	for _, tt := range tests {
+		h := House{ListedSince: tt.listedSince}
+		if got := h.DaysOnMarketAt(now); got != tt.exp {
+			t.Errorf("DaysOnMarketAt() with %v = %v, expected %v", tt.listedSince, got, tt.exp)
+		}
+	}
+}
+
+func TestParseDetailsMunicipalityNeighborhood(t *testing.T) {
+	body := `[{"Section":12,"List":[
+		{"Title":"Omgeving","List":[
+			{"Label":"Wijk","Value":"Oud-West"},
+			{"Label":"Buurt","Value":"Hallenkwartier"},
+			{"Label":"Gemeente","Value":"Amsterdam"}
+		]},
+		{"Label":"Ligging","Value":"Aan water"}
+	]}]`
+
+	var h House
+	if err := h.parseDetailsFromAPIResponse(strings.NewReader(body), nopLogger{}, time.Now()); err != nil {
+		t.Fatalf("Got: %v, expected %v", err, nil)
+	}
+
+	if h.Municipality != "Amsterdam" || h.Neighborhood != "Hallenkwartier" {
+		t.Fatalf("Got: %q, %q; expected %q, %q", h.Municipality, h.Neighborhood, "Amsterdam", "Hallenkwartier")
+	}
+}
+
+func TestParseDetailsSpecialConditions(t *testing.T) {
+	body := `[{"Section":12,"List":[
+		{"Title":"Overdracht","List":[
+			{"Label":"Eigendomssituatie","Value":"Volle eigendom, maatschappelijk gebonden eigendom"},
+			{"Label":"Bijzonderheden","Value":"Beschikbaar voor Koopgarant"},
+			{"Label":"Koopgarant","Value":"Ja"},
+			{"Label":"Zelfbewoningsplicht","Value":"Nee"}
+		]}
+	]}]`
+
+	var h House
+	if err := h.parseDetailsFromAPIResponse(strings.NewReader(body), nopLogger{}, time.Now()); err != nil {
+		t.Fatalf("Got: %v, expected %v", err, nil)
+	}
+
+	exp := []string{"Maatschappelijk gebonden eigendom", "Koopgarant"}
+	if !reflect.DeepEqual(h.SpecialConditions, exp) {
+		t.Fatalf("Got: %q, expected %q", h.SpecialConditions, exp)
+	}
+}
+
+func TestParseDetailsKitchen(t *testing.T) {
+	body := `[{"Section":12,"List":[
+		{"Title":"Indeling","List":[
+			{"Title":"Keuken","List":[
+				{"Label":"Soort keuken","Value":"Open keuken"},
+				{"Label":"Apparatuur","Value":"Vaatwasser,\u00a0oven  en inductiekookplaat "}
+			]}
+		]}
+	]}]`
+
+	var h House
+	if err := h.parseDetailsFromAPIResponse(strings.NewReader(body), nopLogger{}, time.Now()); err != nil {
+		t.Fatalf("Got: %v, expected %v", err, nil)
+	}
+
+	if h.KitchenType != "Open keuken" {
+		t.Fatalf("Got: %q, expected %q", h.KitchenType, "Open keuken")
+	}
+	exp := []string{"Vaatwasser", "oven", "inductiekookplaat"}
+	if !reflect.DeepEqual(h.KitchenAppliances, exp) {
+		t.Fatalf("Got: %q, expected %q", h.KitchenAppliances, exp)
+	}
+}
@@ -0,0 +1,115 @@
+package funda
+
+import (
+	"reflect"
+	"sort"
+)
+
+// MergeHouses concatenates lists of houses, de-duplicated by ID. Houses are
+// ordered by first occurrence. When a house occurs more than once, the most
+// complete copy (the one with the most non-zero fields) is kept.
+func MergeHouses(lists ...[]*House) []*House {
+	var merged []*House
+	index := make(map[int]int)
+
+	for _, list := range lists {
+		for _, house := range list {
+			i, ok := index[house.ID]
+			if !ok {
+				index[house.ID] = len(merged)
+				merged = append(merged, house)
+				continue
+			}
+			if completeness(house) > completeness(merged[i]) {
+				merged[i] = house
+			}
+		}
+	}
+
+	return merged
+}
+
+// completeness returns the number of fields of h that are set.
+func completeness(h *House) int {
+	v := reflect.ValueOf(h).Elem()
+
+	n := 0
+	for i := 0; i < v.NumField(); i++ {
+		if !v.Field(i).IsZero() {
+			n++
+		}
+	}
+
+	return n
+}
+
+// FilterHouses returns the houses for which pred returns true.
+func FilterHouses(houses []*House, pred func(*House) bool) []*House {
+	var filtered []*House
+	for _, house := range houses {
+		if pred(house) {
+			filtered = append(filtered, house)
+		}
+	}
+	return filtered
+}
+
+// PriceBetween returns a predicate for FilterHouses matching houses with a
+// price in euros between min and max, inclusive.
+func PriceBetween(min, max int) func(*House) bool {
+	return func(h *House) bool {
+		return h.PriceEUR >= min && h.PriceEUR <= max
+	}
+}
+
+// MinBedrooms returns a predicate for FilterHouses matching houses with at
+// least n bedrooms.
+func MinBedrooms(n int) func(*House) bool {
+	return func(h *House) bool {
+		return h.Bedrooms >= n
+	}
+}
+
+// SortField defines the field houses are sorted by with SortHouses.
+type SortField int
+
+// Fields to sort houses by.
+const (
+	SortPrice SortField = iota
+	SortArea
+	SortRooms
+	SortPricePerM2
+	SortYearBuilt
+)
+
+func (f SortField) value(h *House) float64 {
+	switch f {
+	case SortPrice:
+		return float64(h.PriceEUR)
+	case SortArea:
+		return float64(h.LivingAreaM2)
+	case SortRooms:
+		return float64(h.TotalRooms)
+	case SortPricePerM2:
+		return h.PricePerM2()
+	case SortYearBuilt:
+		return float64(h.YearBuilt)
+	}
+	return 0
+}
+
+// SortHouses sorts houses in place by the given field. Houses for which the
+// field is unknown (zero) are placed last, regardless of direction.
+func SortHouses(houses []*House, by SortField, ascending bool) {
+	sort.SliceStable(houses, func(i, j int) bool {
+		a, b := by.value(houses[i]), by.value(houses[j])
+		switch {
+		case a == 0 || b == 0:
+			return b == 0 && a != 0
+		case ascending:
+			return a < b
+		default:
+			return a > b
+		}
+	})
+}
@@ -0,0 +1,87 @@
+package funda
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestMergeHouses(t *testing.T) {
+	listingOnly := &House{ID: 2, Address: "Buiksloterbreek 65"}
+	detailed := &House{ID: 2, Address: "Buiksloterbreek 65", PriceEUR: 400000, LivingAreaM2: 68}
+
+	a := []*House{{ID: 1}, listingOnly}
+	b := []*House{{ID: 3}, detailed, {ID: 1}}
+
+	got := MergeHouses(a, b)
+
+	var ids []int
+	for _, h := range got {
+		ids = append(ids, h.ID)
+	}
+	if exp := []int{1, 2, 3}; !reflect.DeepEqual(ids, exp) {
+		t.Fatalf("Got: %v, expected %v", ids, exp)
+	}
+
+	if got[1] != detailed {
+		t.Fatalf("Got: %+v, expected %+v", *got[1], *detailed)
+	}
+}
+
+func TestFilterHouses(t *testing.T) {
+	houses := []*House{
+		{ID: 1, PriceEUR: 250000, Bedrooms: 1},
+		{ID: 2, PriceEUR: 400000, Bedrooms: 2},
+		{ID: 3, PriceEUR: 600000, Bedrooms: 3},
+	}
+
+	tests := []struct {
+		pred func(*House) bool
+		exp  []int
+	}{
+		{PriceBetween(250000, 400000), []int{1, 2}},
+		{MinBedrooms(2), []int{2, 3}},
+		{MinBedrooms(4), nil},
+	}
+
+	for _, tt := range tests {
+		var ids []int
+		for _, h := range FilterHouses(houses, tt.pred) {
+			ids = append(ids, h.ID)
+		}
+		if !reflect.DeepEqual(ids, tt.exp) {
+			t.Errorf("Got: %v, expected %v", ids, tt.exp)
+		}
+	}
+}
+
+func TestSortHouses(t *testing.T) {
+	tests := []struct {
+		by        SortField
+		ascending bool
+		exp       []int
+	}{
+		{SortPrice, true, []int{2, 1, 3, 4}},
+		{SortPrice, false, []int{3, 1, 2, 4}},
+		{SortArea, true, []int{2, 1, 3, 4}},
+		{SortPricePerM2, false, []int{2, 1, 3, 4}},
+	}
+
+	for _, tt := range tests {
+		houses := []*House{
+			{ID: 1, PriceEUR: 400000, LivingAreaM2: 68},
+			{ID: 2, PriceEUR: 300000, LivingAreaM2: 40},
+			{ID: 3, PriceEUR: 500000, LivingAreaM2: 120},
+			{ID: 4},
+		}
+
+		SortHouses(houses, tt.by, tt.ascending)
+
+		var ids []int
+		for _, h := range houses {
+			ids = append(ids, h.ID)
+		}
+		if !reflect.DeepEqual(ids, tt.exp) {
+			t.Errorf("SortHouses(%v, %v): got %v, expected %v", tt.by, tt.ascending, ids, tt.exp)
+		}
+	}
+}
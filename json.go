@@ -0,0 +1,127 @@
+package funda
+
+import (
+	"encoding/json"
+	"net/url"
+)
+
+// houseAlias has the fields of House, without its methods, so it can be
+// (un)marshaled without recursing into House.MarshalJSON.
+type houseAlias House
+
+// MarshalJSON implements json.Marshaler. URLs are encoded as strings.
+func (h House) MarshalJSON() ([]byte, error) {
+	v := struct {
+		houseAlias
+		URL       string   `json:"URL"`
+		ImageURL  string   `json:"ImageURL"`
+		ImageURLs []string `json:"ImageURLs"`
+	}{
+		houseAlias: houseAlias(h),
+		URL:        h.URL.String(),
+		ImageURL:   h.ImageURL.String(),
+		ImageURLs:  urlStrings(h.ImageURLs),
+	}
+
+	return json.Marshal(v)
+}
+
+// UnmarshalJSON implements json.Unmarshaler, decoding URLs from strings.
+func (h *House) UnmarshalJSON(data []byte) error {
+	v := struct {
+		*houseAlias
+		URL       string   `json:"URL"`
+		ImageURL  string   `json:"ImageURL"`
+		ImageURLs []string `json:"ImageURLs"`
+	}{
+		houseAlias: (*houseAlias)(h),
+	}
+
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+
+	houseURL, err := url.Parse(v.URL)
+	if err != nil {
+		return err
+	}
+	h.URL = *houseURL
+
+	imageURL, err := url.Parse(v.ImageURL)
+	if err != nil {
+		return err
+	}
+	h.ImageURL = *imageURL
+
+	h.ImageURLs, err = parseURLs(v.ImageURLs)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func urlStrings(urls []url.URL) []string {
+	if urls == nil {
+		return nil
+	}
+
+	s := make([]string, len(urls))
+	for i := range urls {
+		s[i] = urls[i].String()
+	}
+
+	return s
+}
+
+func parseURLs(s []string) ([]url.URL, error) {
+	if s == nil {
+		return nil, nil
+	}
+
+	urls := make([]url.URL, len(s))
+	for i := range s {
+		u, err := url.Parse(s[i])
+		if err != nil {
+			return nil, err
+		}
+		urls[i] = *u
+	}
+
+	return urls, nil
+}
+
+// MarshalJSON implements json.Marshaler. The URL is encoded as a string.
+func (a Agent) MarshalJSON() ([]byte, error) {
+	type agentAlias Agent
+	return json.Marshal(struct {
+		agentAlias
+		URL string `json:"URL"`
+	}{
+		agentAlias: agentAlias(a),
+		URL:        a.URL.String(),
+	})
+}
+
+// UnmarshalJSON implements json.Unmarshaler, decoding the URL from a string.
+func (a *Agent) UnmarshalJSON(data []byte) error {
+	type agentAlias Agent
+	v := struct {
+		*agentAlias
+		URL string `json:"URL"`
+	}{
+		agentAlias: (*agentAlias)(a),
+	}
+
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+
+	agentURL, err := url.Parse(v.URL)
+	if err != nil {
+		return err
+	}
+	a.URL = *agentURL
+
+	return nil
+}
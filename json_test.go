@@ -0,0 +1,51 @@
+package funda
+
+import (
+	"encoding/json"
+	"net/url"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestHouseJSONRoundTrip(t *testing.T) {
+	exp := House{
+		ID:             4094475,
+		ListingType:    ListingRent,
+		Address:        "Buiksloterbreek 65",
+		PriceRaw:       "€ 400.000 k.k.",
+		PriceEUR:       400000,
+		PriceCondition: PriceConditionKostenKoper,
+		URL:            parseURL("https://www.funda.nl/40443683"),
+		ImageURL:       parseURL("https://cloud.funda.nl/valentina_media/090/700/422_720x480.jpg"),
+		ImageURLs: []url.URL{
+			parseURL("https://cloud.funda.nl/valentina_media/090/700/422_720x480.jpg"),
+		},
+		LivingAreaM2: 68,
+		Latitude:     52.371685,
+		Longitude:    4.872972,
+		Agent: Agent{
+			Name:  "Zelfverkopen.nl",
+			Phone: "088 235 0111",
+			URL:   parseURL("https://www.funda.nl/makelaars/24749"),
+		},
+	}
+
+	data, err := json.Marshal(exp)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !strings.Contains(string(data), `"URL":"https://www.funda.nl/40443683"`) {
+		t.Fatalf("Got: %s, expected URL encoded as string", data)
+	}
+
+	var got House
+	if err := json.Unmarshal(data, &got); err != nil {
+		t.Fatal(err)
+	}
+
+	if !reflect.DeepEqual(got, exp) {
+		t.Fatalf("Got: %+v, expected %+v", got, exp)
+	}
+}
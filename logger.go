@@ -0,0 +1,24 @@
+package funda
+
+// Logger is used by the Client to log non-fatal errors, such as houses that
+// are skipped because their details could not be fetched. It is satisfied by
+// *log.Logger.
+type Logger interface {
+	Printf(format string, args ...interface{})
+}
+
+type nopLogger struct{}
+
+func (nopLogger) Printf(format string, args ...interface{}) {}
+
+// logger returns the configured Logger, or a no-op Logger if none is set.
+func (c *Client) logger() Logger {
+	if c.Logger == nil {
+		return nopLogger{}
+	}
+	return c.Logger
+}
+
+func (c *Client) logf(format string, args ...interface{}) {
+	c.logger().Printf(format, args...)
+}
@@ -0,0 +1,65 @@
+package funda
+
+import (
+	"bytes"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+)
+
+type testLogger struct {
+	mu   sync.Mutex
+	msgs []string
+}
+
+func (l *testLogger) Printf(format string, args ...interface{}) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.msgs = append(l.msgs, fmt.Sprintf(format, args...))
+}
+
+func TestLogger(t *testing.T) {
+	// Append an incomplete listing to the valid ones.
+	searchResp := searchResponseWithIDs(t, 1, 2)
+	searchResp = append(bytes.TrimSuffix(bytes.TrimSpace(searchResp), []byte("]")),
+		[]byte(`,{"ItemType":1,"GlobalId":3,"Info":[{"Line":[{"Text":"Incomplete"}]}]}]`)...)
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/Aanbod/Detail/Koop/1":
+			http.ServeFile(w, r, "test_data/funda_house_response.json")
+		case "/Aanbod/Detail/Koop/2":
+			w.WriteHeader(http.StatusNotFound)
+		default:
+			w.Write(searchResp)
+		}
+	}))
+	defer ts.Close()
+
+	logger := &testLogger{}
+
+	fundaClient := NewClient("foobar")
+	fundaClient.BaseURL = ts.URL
+	fundaClient.Logger = logger
+
+	got, err := fundaClient.Search("", 0, 0)
+	if err != nil {
+		t.Fatalf("Got: %v, expected %v", err, nil)
+	}
+	if len(got) != 1 || got[0].ID != 1 {
+		t.Fatalf("Got: %v, expected only house 1", got)
+	}
+
+	exp := []string{"Error: Skipping house (3)", "Error: Could not get house (2)"}
+	if len(logger.msgs) != len(exp) {
+		t.Fatalf("Got: %q, expected %v messages", logger.msgs, len(exp))
+	}
+	for i, prefix := range exp {
+		if !strings.HasPrefix(logger.msgs[i], prefix) {
+			t.Errorf("Got: %q, expected prefix %q", logger.msgs[i], prefix)
+		}
+	}
+}
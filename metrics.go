@@ -0,0 +1,32 @@
+package funda
+
+import "time"
+
+// Metrics is used by the Client to observe every HTTP request it does, e.g.
+// to record request latencies and status codes as Prometheus metrics.
+type Metrics interface {
+	// ObserveRequest is called after every request with the route of the
+	// request, the response status code (0 when no response was received) and
+	// the duration of the request. The route is RouteSearch or RouteDetail
+	// rather than the path, which contains search options and house IDs, so
+	// it can be used as a metric label.
+	ObserveRequest(route string, status int, dur time.Duration)
+}
+
+// Routes passed to Metrics.ObserveRequest.
+const (
+	RouteSearch = "search"
+	RouteDetail = "detail"
+)
+
+type nopMetrics struct{}
+
+func (nopMetrics) ObserveRequest(route string, status int, dur time.Duration) {}
+
+// metrics returns the configured Metrics, or a no-op Metrics if none is set.
+func (c *Client) metrics() Metrics {
+	if c.Metrics == nil {
+		return nopMetrics{}
+	}
+	return c.Metrics
+}
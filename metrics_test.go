@@ -0,0 +1,55 @@
+package funda
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+type testMetrics struct {
+	mu       sync.Mutex
+	requests map[string]int
+}
+
+func (m *testMetrics) ObserveRequest(route string, status int, dur time.Duration) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.requests[route] = status
+}
+
+func TestMetrics(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasPrefix(r.URL.Path, "/Aanbod/Detail/") {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Write(searchResponseWithIDs(t, 1))
+	}))
+	defer ts.Close()
+
+	metrics := &testMetrics{requests: make(map[string]int)}
+
+	fundaClient := NewClient("foobar")
+	fundaClient.BaseURL = ts.URL
+	fundaClient.Metrics = metrics
+
+	if _, err := fundaClient.Search("", 1, 25); err != nil {
+		t.Fatalf("Got: %v, expected %v", err, nil)
+	}
+
+	exp := map[string]int{
+		RouteSearch: http.StatusOK,
+		RouteDetail: http.StatusNotFound,
+	}
+	for route, status := range exp {
+		if got := metrics.requests[route]; got != status {
+			t.Errorf("Got status %v for %v, expected %v", got, route, status)
+		}
+	}
+	if len(metrics.requests) != len(exp) {
+		t.Errorf("Got routes %v, expected %v", metrics.requests, exp)
+	}
+}
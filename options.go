@@ -0,0 +1,71 @@
+package funda
+
+import (
+	"net/http"
+	"time"
+)
+
+// Option configures a Client created with NewClientWithOptions.
+type Option func(*Client)
+
+// WithHTTPClient sets the HTTP client used for requests.
+func WithHTTPClient(httpClient *http.Client) Option {
+	return func(c *Client) {
+		c.HTTPClient = httpClient
+	}
+}
+
+// WithBaseURL sets the base URL of the Funda API.
+func WithBaseURL(baseURL string) Option {
+	return func(c *Client) {
+		c.BaseURL = baseURL
+	}
+}
+
+// WithLogger sets the Logger for non-fatal errors.
+func WithLogger(logger Logger) Option {
+	return func(c *Client) {
+		c.Logger = logger
+	}
+}
+
+// WithRateLimiter sets the RateLimiter waited on before every request.
+func WithRateLimiter(limiter RateLimiter) Option {
+	return func(c *Client) {
+		c.RateLimiter = limiter
+	}
+}
+
+// WithTransport sets the transport of the HTTP client configured so far, like
+// SetTransport, so it must follow WithHTTPClient.
+func WithTransport(rt http.RoundTripper) Option {
+	return func(c *Client) {
+		c.SetTransport(rt)
+	}
+}
+
+// SetTransport replaces the HTTPClient with a copy that uses rt as its
+// transport, e.g. for mocking or tracing requests. Other settings, like the
+// timeout, are preserved, and the previous HTTPClient is left unchanged.
+func (c *Client) SetTransport(rt http.RoundTripper) {
+	httpClient := http.Client{}
+	if c.HTTPClient != nil {
+		httpClient = *c.HTTPClient
+	}
+	httpClient.Transport = rt
+	c.HTTPClient = &httpClient
+}
+
+// WithTimeout sets the timeout of every request, replacing the default of 30
+// seconds. It applies to a copy of the HTTP client configured so far, so it
+// must follow WithHTTPClient, and never changes the given client.
+func WithTimeout(timeout time.Duration) Option {
+	return func(c *Client) {
+		httpClient := http.Client{}
+		if c.HTTPClient != nil {
+			httpClient = *c.HTTPClient
+		}
+		httpClient.Timeout = timeout
+		c.HTTPClient = &httpClient
+	}
+}
@@ -0,0 +1,85 @@
+package funda
+
+import (
+	"io"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestNewClientDefaultTimeout(t *testing.T) {
+	fundaClient := NewClient("foobar")
+	if fundaClient.HTTPClient.Timeout != defaultTimeout {
+		t.Fatalf("Got: %v, expected %v", fundaClient.HTTPClient.Timeout, defaultTimeout)
+	}
+
+	// Every client has its own HTTP client, so options don't leak.
+	if NewClient("foobar").HTTPClient == fundaClient.HTTPClient {
+		t.Fatal("Expected clients not to share an HTTP client")
+	}
+}
+
+func TestNewClientWithOptions(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		time.Sleep(50 * time.Millisecond)
+		w.Write([]byte("[]"))
+	}))
+	defer ts.Close()
+
+	httpClient := &http.Client{}
+	logger := log.New(io.Discard, "", 0)
+	fundaClient := NewClientWithOptions("foobar",
+		WithHTTPClient(httpClient),
+		WithBaseURL(ts.URL),
+		WithLogger(logger),
+		WithTimeout(10*time.Millisecond),
+	)
+
+	if fundaClient.BaseURL != ts.URL {
+		t.Fatalf("Got: %v, expected %v", fundaClient.BaseURL, ts.URL)
+	}
+	if fundaClient.Logger != logger {
+		t.Fatalf("Got: %v, expected %v", fundaClient.Logger, logger)
+	}
+	if httpClient.Timeout != 0 {
+		t.Fatal("Expected timeout not to change the given HTTP client")
+	}
+
+	if _, err := fundaClient.Search("", 0, 0); err == nil {
+		t.Fatal("Expected timeout error")
+	}
+}
+
+type roundTripperFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripperFunc) RoundTrip(r *http.Request) (*http.Response, error) {
+	return f(r)
+}
+
+func TestWithTransport(t *testing.T) {
+	var reqs int
+	rt := roundTripperFunc(func(r *http.Request) (*http.Response, error) {
+		reqs++
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Header:     http.Header{},
+			Body:       io.NopCloser(strings.NewReader("[]")),
+			Request:    r,
+		}, nil
+	})
+
+	fundaClient := NewClientWithOptions("foobar", WithTimeout(5*time.Second), WithTransport(rt))
+
+	if fundaClient.HTTPClient.Timeout != 5*time.Second {
+		t.Fatalf("Got: %v, expected %v", fundaClient.HTTPClient.Timeout, 5*time.Second)
+	}
+	if _, err := fundaClient.Search("", 0, 0); err != nil {
+		t.Fatalf("Got: %v, expected %v", err, nil)
+	}
+	if reqs != 1 {
+		t.Fatalf("Got: %v requests, expected %v", reqs, 1)
+	}
+}
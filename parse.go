@@ -0,0 +1,421 @@
+package funda
+
+import (
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// normalizeSpace replaces (narrow) non-breaking spaces, as used by the Funda
+// API, with regular spaces and trims the result.
+func normalizeSpace(s string) string {
+	return strings.TrimSpace(nbspReplacer.Replace(s))
+}
+
+var nbspReplacer = strings.NewReplacer("\u00a0", " ", "\u202f", " ")
+
+// parseInt returns the first integer found in s, ignoring "." thousands
+// separators. A decimal part (after a ",") is discarded.
+func parseInt(s string) (int, bool) {
+	start := strings.IndexAny(s, "0123456789")
+	if start == -1 {
+		return 0, false
+	}
+
+	var digits strings.Builder
+loop:
+	for _, r := range s[start:] {
+		switch {
+		case r >= '0' && r <= '9':
+			digits.WriteRune(r)
+		case r == '.':
+			// Thousands separator.
+		default:
+			break loop
+		}
+	}
+
+	n, err := strconv.Atoi(digits.String())
+	if err != nil {
+		return 0, false
+	}
+
+	return n, true
+}
+
+// parsePrice parses a price value like "€ 400.000 k.k.".
+func parsePrice(s string) (eur int, cond PriceCondition, onRequest bool) {
+	s = strings.ToLower(normalizeSpace(s))
+
+	if strings.Contains(s, "op aanvraag") {
+		return 0, PriceConditionUnknown, true
+	}
+
+	switch {
+	case strings.HasSuffix(s, "k.k."):
+		cond = PriceConditionKostenKoper
+	case strings.HasSuffix(s, "v.o.n."):
+		cond = PriceConditionVrijOpNaam
+	}
+
+	eur, _ = parseInt(s)
+
+	return eur, cond, false
+}
+
+// parsePriceRange parses a price range like "€ 350.000 tot € 420.000". For a
+// single price, min and max are both that price. As with parsePrice, the
+// minimum is the first amount.
+func parsePriceRange(s string) (min, max int) {
+	s = strings.ToLower(normalizeSpace(s))
+
+	min, _ = parseInt(s)
+	max = min
+
+	for _, sep := range []string{" tot ", " - ", "-"} {
+		if i := strings.Index(s, sep); i != -1 {
+			if n, ok := parseInt(s[i+len(sep):]); ok && n > min {
+				max = n
+			}
+			break
+		}
+	}
+
+	return min, max
+}
+
+// parseRooms parses a room count like "3 kamers (1 slaapkamer)". Bedrooms are
+// only set when listed explicitly.
+func parseRooms(s string) (total, bedrooms int) {
+	s = strings.ToLower(normalizeSpace(s))
+
+	rooms := s
+	if i := strings.Index(s, "("); i != -1 {
+		rooms = s[:i]
+		if details := s[i:]; strings.Contains(details, "slaapkamer") {
+			bedrooms, _ = parseInt(details)
+		}
+	}
+
+	if strings.Contains(rooms, "kamer") {
+		total, _ = parseInt(rooms)
+	}
+
+	return total, bedrooms
+}
+
+// parseYear parses a year like "1906". For ranges ("1920-1930") or other
+// text ("Voor 1906") the first year found is returned, with approx set.
+func parseYear(s string) (year int, approx bool) {
+	s = normalizeSpace(s)
+
+	year, ok := parseInt(s)
+	if !ok || year < 1000 || year > 9999 {
+		return 0, false
+	}
+
+	return year, s != strconv.Itoa(year)
+}
+
+var postalCodeCityRegexp = regexp.MustCompile(`^([1-9][0-9]{3}) ?([A-Za-z]{2})\s+(.+)$`)
+
+// parsePostalCodeCity parses a line like "1052 ND Amsterdam" into a postal
+// code, formatted as "1052 ND", and a city.
+func parsePostalCodeCity(s string) (postalCode, city string, ok bool) {
+	m := postalCodeCityRegexp.FindStringSubmatch(normalizeSpace(s))
+	if m == nil {
+		return "", "", false
+	}
+
+	return m[1] + " " + strings.ToUpper(m[2]), strings.TrimSpace(m[3]), true
+}
+
+// parseBathrooms parses a value like "1 badkamer en 1 apart toilet".
+func parseBathrooms(s string) (bathrooms, toilets int) {
+	for _, part := range strings.Split(strings.ToLower(normalizeSpace(s)), " en ") {
+		switch {
+		case strings.Contains(part, "badkamer"):
+			bathrooms, _ = parseInt(part)
+		case strings.Contains(part, "toilet"):
+			toilets, _ = parseInt(part)
+		}
+	}
+
+	return bathrooms, toilets
+}
+
+var (
+	numericDateRegexp = regexp.MustCompile(`\b(\d{1,2})-(\d{1,2})-(\d{4})\b`)
+	writtenDateRegexp = regexp.MustCompile(`\b(\d{1,2}) ([a-z]+) (\d{4})\b`)
+)
+
+var dutchMonths = map[string]time.Month{
+	"januari":   time.January,
+	"februari":  time.February,
+	"maart":     time.March,
+	"april":     time.April,
+	"mei":       time.May,
+	"juni":      time.June,
+	"juli":      time.July,
+	"augustus":  time.August,
+	"september": time.September,
+	"oktober":   time.October,
+	"november":  time.November,
+	"december":  time.December,
+}
+
+// parseDate returns the first date found in s, written either as
+// "31-07-2024" or as "31 juli 2024". Dates are in the Europe/Amsterdam time
+// zone when available.
+func parseDate(s string) (time.Time, bool) {
+	s = strings.ToLower(normalizeSpace(s))
+
+	var day, year int
+	var month time.Month
+
+	if m := numericDateRegexp.FindStringSubmatch(s); m != nil {
+		day, _ = strconv.Atoi(m[1])
+		n, _ := strconv.Atoi(m[2])
+		month = time.Month(n)
+		year, _ = strconv.Atoi(m[3])
+	} else if m := writtenDateRegexp.FindStringSubmatch(s); m != nil {
+		day, _ = strconv.Atoi(m[1])
+		month = dutchMonths[m[2]]
+		year, _ = strconv.Atoi(m[3])
+	}
+
+	if day < 1 || day > 31 || month < time.January || month > time.December {
+		return time.Time{}, false
+	}
+
+	t := time.Date(year, month, day, 0, 0, 0, 0, amsterdam)
+	if t.Day() != day {
+		// Overflowing dates, e.g. "31-02-2024".
+		return time.Time{}, false
+	}
+
+	return t, true
+}
+
+var relativeAgeRegexp = regexp.MustCompile(`(\d+)\+?\s+(dag|dagen|week|weken|maand|maanden|jaar)\b`)
+
+// parseListedSince parses when a listing went live, given either as an
+// absolute date ("1 maart 2024") or relative to now ("Vandaag", "3 weken",
+// "6+ maanden"). Relative values are converted to an approximate date, reported by approx.
+func parseListedSince(s string, now time.Time) (t time.Time, approx bool) {
+	if t, ok := parseDate(s); ok {
+		return t, false
+	}
+
+	now = now.In(amsterdam)
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, amsterdam)
+
+	s = strings.ToLower(normalizeSpace(s))
+	switch s {
+	case "vandaag":
+		return today, true
+	case "gisteren":
+		return today.AddDate(0, 0, -1), true
+	case "eergisteren":
+		return today.AddDate(0, 0, -2), true
+	}
+
+	m := relativeAgeRegexp.FindStringSubmatch(s)
+	if m == nil {
+		return time.Time{}, false
+	}
+	n, _ := strconv.Atoi(m[1])
+
+	switch m[2] {
+	case "dag", "dagen":
+		return today.AddDate(0, 0, -n), true
+	case "week", "weken":
+		return today.AddDate(0, 0, -7*n), true
+	case "maand", "maanden":
+		return today.AddDate(0, -n, 0), true
+	default:
+		return today.AddDate(-n, 0, 0), true
+	}
+}
+
+// amsterdam is the time zone dates on Funda are given in.
+var amsterdam = func() *time.Location {
+	loc, err := time.LoadLocation("Europe/Amsterdam")
+	if err != nil {
+		return time.UTC
+	}
+	return loc
+}()
+
+// parseListingStatus parses a status like "Verkocht onder voorbehoud". Rental
+// statuses ("Verhuurd") map to their sale equivalents.
+func parseListingStatus(s string) ListingStatus {
+	s = strings.ToLower(normalizeSpace(s))
+
+	switch {
+	case strings.Contains(s, "onder voorbehoud"):
+		return StatusSoldSubjectTo
+	case strings.Contains(s, "verkocht"), strings.Contains(s, "verhuurd"):
+		return StatusSold
+	case strings.Contains(s, "onder bod"), strings.Contains(s, "onder optie"):
+		return StatusUnderBid
+	}
+	return StatusAvailable
+}
+
+// parseStatusLabel parses a status label as shown on search results, e.g.
+// "Onder bod". Unlike parseListingStatus, s must consist of only the label.
+func parseStatusLabel(s string) (ListingStatus, bool) {
+	switch strings.ToLower(normalizeSpace(s)) {
+	case "onder bod", "onder optie":
+		return StatusUnderBid, true
+	case "verkocht onder voorbehoud", "verhuurd onder voorbehoud":
+		return StatusSoldSubjectTo, true
+	case "verkocht", "verhuurd":
+		return StatusSold, true
+	}
+	return StatusAvailable, false
+}
+
+// parseGardenOrientation parses a garden orientation like "Gelegen op het
+// zuidwesten bereikbaar via achterom" into its compass direction.
+func parseGardenOrientation(s string) string {
+	s = strings.ToLower(normalizeSpace(s))
+
+	if i := strings.Index(s, "op het "); i != -1 {
+		s = s[i+len("op het "):]
+		if fields := strings.Fields(s); len(fields) > 0 {
+			return fields[0]
+		}
+	}
+
+	return s
+}
+
+// parseFloor parses the floor an apartment is located on, e.g. "2e woonlaag".
+// The ground floor ("begane grond") is 0.
+func parseFloor(s string) int {
+	s = strings.ToLower(normalizeSpace(s))
+
+	if strings.Contains(s, "begane grond") {
+		return 0
+	}
+
+	floor, ok := parseInt(s)
+	if !ok {
+		return FloorUnknown
+	}
+
+	return floor
+}
+
+// splitList splits an enumeration like "Dakisolatie, muurisolatie en dubbel
+// glas" into its trimmed items.
+func splitList(s string) []string {
+	s = normalizeSpace(s)
+	if s == "" {
+		return nil
+	}
+
+	var items []string
+	for _, part := range strings.Split(s, ",") {
+		for _, item := range strings.Split(part, " en ") {
+			if item = strings.TrimSpace(item); item != "" {
+				items = append(items, item)
+			}
+		}
+	}
+
+	return items
+}
+
+// parseAnnualCost parses a recurring cost like "€ 127,86 per jaar" or
+// "€ 69,98 per halfjaar" into whole euros per year.
+func parseAnnualCost(s string) int {
+	eur, _ := parseInt(s)
+
+	s = strings.ToLower(normalizeSpace(s))
+	switch {
+	case strings.Contains(s, "per maand"), strings.Contains(s, "/mnd"):
+		return eur * 12
+	case strings.Contains(s, "per kwartaal"):
+		return eur * 4
+	case strings.Contains(s, "per halfjaar"), strings.Contains(s, "per half jaar"):
+		return eur * 2
+	default:
+		return eur
+	}
+}
+
+var roomDetailRegexp = regexp.MustCompile(`^(.*?)\s+(\d+)\s*m²`)
+
+// parseRoomDetail parses a room with its area, like "Woonkamer 28 m²".
+func parseRoomDetail(s string) (RoomDetail, bool) {
+	m := roomDetailRegexp.FindStringSubmatch(normalizeSpace(s))
+	if m == nil {
+		return RoomDetail{}, false
+	}
+
+	area, _ := strconv.Atoi(m[2])
+
+	return RoomDetail{Name: m[1], AreaM2: area}, true
+}
+
+// parseInsulationType normalizes an insulation term like "Dubbel glas",
+// regardless of spacing, hyphenation and casing.
+func parseInsulationType(s string) InsulationType {
+	s = strings.ToLower(normalizeSpace(s))
+	s = strings.NewReplacer(" ", "", "-", "", "ï", "i").Replace(s)
+
+	switch {
+	case strings.Contains(s, "driedubbel"), strings.Contains(s, "tripleglas"):
+		return InsulationTripleGlazing
+	case strings.Contains(s, "dubbelglas"), strings.Contains(s, "hrglas"),
+		strings.Contains(s, "hr+glas"), strings.Contains(s, "hr++glas"):
+		return InsulationDoubleGlazing
+	case strings.Contains(s, "voorzetramen"):
+		return InsulationSecondaryGlazing
+	case strings.Contains(s, "dakisolatie"):
+		return InsulationRoof
+	case strings.Contains(s, "muurisolatie"), strings.Contains(s, "gevelisolatie"):
+		return InsulationWall
+	case strings.Contains(s, "vloerisolatie"), strings.Contains(s, "bodemisolatie"):
+		return InsulationFloor
+	case strings.Contains(s, "volledigge") && strings.Contains(s, "isoleerd"):
+		return InsulationFull
+	}
+	return InsulationOther
+}
+
+// isCanonicalURL returns whether u is a page on the public funda.nl website.
+func isCanonicalURL(u *url.URL) bool {
+	return u.Scheme == "https" && u.Host == "www.funda.nl"
+}
+
+var (
+	slugSegmentRegexp = regexp.MustCompile(`^[0-9]+$`)
+	slugRegexp        = regexp.MustCompile(`/[a-z]+-([0-9]+)-`)
+)
+
+// fundaSlug returns the numeric listing ID in a funda.nl URL, e.g. "40443683"
+// for "https://www.funda.nl/40443683",
+// "https://www.funda.nl/detail/koop/amsterdam/appartement-de-clercqstraat-20-1/40443683/"
+// or "https://www.funda.nl/koop/amsterdam/appartement-40443683-de-clercqstraat-20-1/".
+// The last path segment of only digits is preferred, as other segments can
+// contain house numbers.
+func fundaSlug(u *url.URL) string {
+	segments := strings.Split(u.Path, "/")
+	for i := len(segments) - 1; i >= 0; i-- {
+		if slugSegmentRegexp.MatchString(segments[i]) {
+			return segments[i]
+		}
+	}
+
+	m := slugRegexp.FindStringSubmatch(u.Path)
+	if m == nil {
+		return ""
+	}
+	return m[1]
+}
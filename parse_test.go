@@ -0,0 +1,354 @@
+package funda
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestParsePrice(t *testing.T) {
+	tests := []struct {
+		in        string
+		eur       int
+		cond      PriceCondition
+		onRequest bool
+	}{
+		{"€\u00a0400.000 k.k.", 400000, PriceConditionKostenKoper, false},
+		{"€ 1.250.000 v.o.n.", 1250000, PriceConditionVrijOpNaam, false},
+		{"€ 275.000", 275000, PriceConditionUnknown, false},
+		{"Prijs op aanvraag", 0, PriceConditionUnknown, true},
+	}
+
+	for _, tt := range tests {
+		eur, cond, onRequest := parsePrice(tt.in)
+		if eur != tt.eur || cond != tt.cond || onRequest != tt.onRequest {
+			t.Errorf("parsePrice(%q) = %v, %v, %v; expected %v, %v, %v",
+				tt.in, eur, cond, onRequest, tt.eur, tt.cond, tt.onRequest)
+		}
+	}
+}
+
+func TestParsePriceRange(t *testing.T) {
+	tests := []struct {
+		in  string
+		min int
+		max int
+	}{
+		{"€ 350.000 tot € 420.000 v.o.n.", 350000, 420000},
+		{"van €\u00a0350.000 - €\u00a0420.000", 350000, 420000},
+		{"€ 400.000 k.k.", 400000, 400000},
+		{"Prijs op aanvraag", 0, 0},
+	}
+
+	for _, tt := range tests {
+		min, max := parsePriceRange(tt.in)
+		if min != tt.min || max != tt.max {
+			t.Errorf("parsePriceRange(%q) = %v, %v; expected %v, %v", tt.in, min, max, tt.min, tt.max)
+		}
+	}
+}
+
+func TestParseInt(t *testing.T) {
+	tests := []struct {
+		in string
+		n  int
+		ok bool
+	}{
+		{"68 m²", 68, true},
+		{"1.250 m²", 1250, true},
+		{"€ 127,86 per jaar", 127, true},
+		{"", 0, false},
+		{"onbekend", 0, false},
+	}
+
+	for _, tt := range tests {
+		n, ok := parseInt(tt.in)
+		if n != tt.n || ok != tt.ok {
+			t.Errorf("parseInt(%q) = %v, %v; expected %v, %v", tt.in, n, ok, tt.n, tt.ok)
+		}
+	}
+}
+
+func TestParseRooms(t *testing.T) {
+	tests := []struct {
+		in       string
+		total    int
+		bedrooms int
+	}{
+		{"3 kamers (1 slaapkamer)", 3, 1},
+		{"5 kamers (3 slaapkamers)", 5, 3},
+		{"1 kamer", 1, 0},
+		{"2 kamers", 2, 0},
+		{"4\u00a0kamers\u00a0(3\u00a0slaapkamers)", 4, 3},
+		{"\u00a04 Kamers\u202f", 4, 0},
+		{"", 0, 0},
+	}
+
+	for _, tt := range tests {
+		total, bedrooms := parseRooms(tt.in)
+		if total != tt.total || bedrooms != tt.bedrooms {
+			t.Errorf("parseRooms(%q) = %v, %v; expected %v, %v",
+				tt.in, total, bedrooms, tt.total, tt.bedrooms)
+		}
+	}
+}
+
+func TestParseYear(t *testing.T) {
+	tests := []struct {
+		in     string
+		year   int
+		approx bool
+	}{
+		{"1906", 1906, false},
+		{"1920-1930", 1920, true},
+		{"Voor 1906", 1906, true},
+		{"Onbekend", 0, false},
+	}
+
+	for _, tt := range tests {
+		year, approx := parseYear(tt.in)
+		if year != tt.year || approx != tt.approx {
+			t.Errorf("parseYear(%q) = %v, %v; expected %v, %v",
+				tt.in, year, approx, tt.year, tt.approx)
+		}
+	}
+}
+
+func TestParsePostalCodeCity(t *testing.T) {
+	tests := []struct {
+		in         string
+		postalCode string
+		city       string
+		ok         bool
+	}{
+		{"1052 ND Amsterdam", "1052 ND", "Amsterdam", true},
+		{"1034 XD  Amsterdam", "1034 XD", "Amsterdam", true},
+		{"2511ab Den Haag", "2511 AB", "Den Haag", true},
+		{"De Clercqstraat 20 1", "", "", false},
+	}
+
+	for _, tt := range tests {
+		postalCode, city, ok := parsePostalCodeCity(tt.in)
+		if postalCode != tt.postalCode || city != tt.city || ok != tt.ok {
+			t.Errorf("parsePostalCodeCity(%q) = %q, %q, %v; expected %q, %q, %v",
+				tt.in, postalCode, city, ok, tt.postalCode, tt.city, tt.ok)
+		}
+	}
+}
+
+func TestParseBathrooms(t *testing.T) {
+	tests := []struct {
+		in        string
+		bathrooms int
+		toilets   int
+	}{
+		{"1 badkamer en 1 apart toilet", 1, 1},
+		{"2 badkamers en 2 aparte toiletten", 2, 2},
+		{"1 badkamer", 1, 0},
+		{"1 Badkamer en 1 apart toilet", 1, 1},
+		{"", 0, 0},
+	}
+
+	for _, tt := range tests {
+		bathrooms, toilets := parseBathrooms(tt.in)
+		if bathrooms != tt.bathrooms || toilets != tt.toilets {
+			t.Errorf("parseBathrooms(%q) = %v, %v; expected %v, %v",
+				tt.in, bathrooms, toilets, tt.bathrooms, tt.toilets)
+		}
+	}
+}
+
+func TestParseDate(t *testing.T) {
+	tests := []struct {
+		in  string
+		exp time.Time
+		ok  bool
+	}{
+		{"31-07-2024", time.Date(2024, time.July, 31, 0, 0, 0, 0, amsterdam), true},
+		{"Per 1 maart 2024", time.Date(2024, time.March, 1, 0, 0, 0, 0, amsterdam), true},
+		{"In overleg", time.Time{}, false},
+		{"31-02-2024", time.Time{}, false},
+	}
+
+	for _, tt := range tests {
+		got, ok := parseDate(tt.in)
+		if !got.Equal(tt.exp) || ok != tt.ok {
+			t.Errorf("parseDate(%q) = %v, %v; expected %v, %v", tt.in, got, ok, tt.exp, tt.ok)
+		}
+	}
+}
+
+func TestParseListedSince(t *testing.T) {
+	now := time.Date(2024, time.March, 20, 15, 0, 0, 0, amsterdam)
+
+	tests := []struct {
+		in     string
+		exp    time.Time
+		approx bool
+	}{
+		{"1 maart 2024", time.Date(2024, time.March, 1, 0, 0, 0, 0, amsterdam), false},
+		{"Vandaag", time.Date(2024, time.March, 20, 0, 0, 0, 0, amsterdam), true},
+		{"Eergisteren", time.Date(2024, time.March, 18, 0, 0, 0, 0, amsterdam), true},
+		{"3 weken", time.Date(2024, time.February, 28, 0, 0, 0, 0, amsterdam), true},
+		{"2 maanden", time.Date(2024, time.January, 20, 0, 0, 0, 0, amsterdam), true},
+		{"6+ maanden", time.Date(2023, time.September, 20, 0, 0, 0, 0, amsterdam), true},
+		{"Onbekend", time.Time{}, false},
+	}
+
+	for _, tt := range tests {
+		got, approx := parseListedSince(tt.in, now)
+		if !got.Equal(tt.exp) || approx != tt.approx {
+			t.Errorf("parseListedSince(%q) = %v, %v; expected %v, %v", tt.in, got, approx, tt.exp, tt.approx)
+		}
+	}
+}
+
+func TestParseAnnualCost(t *testing.T) {
+	tests := []struct {
+		in  string
+		exp int
+	}{
+		{"€ 127,86 per jaar", 127},
+		{"€ 69,98 per halfjaar", 138},
+		{"€ 1.000 per maand", 12000},
+		{"Afgekocht", 0},
+	}
+
+	for _, tt := range tests {
+		if got := parseAnnualCost(tt.in); got != tt.exp {
+			t.Errorf("parseAnnualCost(%q) = %v, expected %v", tt.in, got, tt.exp)
+		}
+	}
+}
+
+func TestParseRoomDetail(t *testing.T) {
+	tests := []struct {
+		in  string
+		exp RoomDetail
+		ok  bool
+	}{
+		{"Woonkamer 28 m²", RoomDetail{Name: "Woonkamer", AreaM2: 28}, true},
+		{"Slaapkamer 1\u00a012\u00a0m²", RoomDetail{Name: "Slaapkamer 1", AreaM2: 12}, true},
+		{"Woonkamer", RoomDetail{}, false},
+	}
+
+	for _, tt := range tests {
+		got, ok := parseRoomDetail(tt.in)
+		if got != tt.exp || ok != tt.ok {
+			t.Errorf("parseRoomDetail(%q) = %v, %v; expected %v, %v", tt.in, got, ok, tt.exp, tt.ok)
+		}
+	}
+}
+
+func TestParseInsulationType(t *testing.T) {
+	tests := []struct {
+		in  string
+		exp InsulationType
+	}{
+		{"Dakisolatie", InsulationRoof},
+		{"spouwmuurisolatie", InsulationWall},
+		{"Dubbel glas", InsulationDoubleGlazing},
+		{"dubbelglas", InsulationDoubleGlazing},
+		{"HR-glas", InsulationDoubleGlazing},
+		{"Driedubbel glas", InsulationTripleGlazing},
+		{"Volledig geïsoleerd", InsulationFull},
+		{"Eco-bouw", InsulationOther},
+	}
+
+	for _, tt := range tests {
+		if got := parseInsulationType(tt.in); got != tt.exp {
+			t.Errorf("parseInsulationType(%q) = %v, expected %v", tt.in, got, tt.exp)
+		}
+	}
+}
+
+func TestParseListingStatus(t *testing.T) {
+	tests := []struct {
+		in  string
+		exp ListingStatus
+	}{
+		{"Beschikbaar", StatusAvailable},
+		{"Onder bod", StatusUnderBid},
+		{"Verkocht onder voorbehoud", StatusSoldSubjectTo},
+		{"Verkocht", StatusSold},
+	}
+
+	for _, tt := range tests {
+		if got := parseListingStatus(tt.in); got != tt.exp {
+			t.Errorf("parseListingStatus(%q) = %v, expected %v", tt.in, got, tt.exp)
+		}
+	}
+}
+
+func TestParseStatusLabel(t *testing.T) {
+	tests := []struct {
+		in  string
+		exp ListingStatus
+		ok  bool
+	}{
+		{"Onder bod", StatusUnderBid, true},
+		{"Verkocht onder voorbehoud", StatusSoldSubjectTo, true},
+		{"Verhuurd", StatusSold, true},
+		{"Verkochtstraat 1", StatusAvailable, false},
+	}
+
+	for _, tt := range tests {
+		got, ok := parseStatusLabel(tt.in)
+		if got != tt.exp || ok != tt.ok {
+			t.Errorf("parseStatusLabel(%q) = %v, %v; expected %v, %v", tt.in, got, ok, tt.exp, tt.ok)
+		}
+	}
+}
+
+func TestParseGardenOrientation(t *testing.T) {
+	tests := []struct {
+		in  string
+		exp string
+	}{
+		{"Gelegen op het zuiden", "zuiden"},
+		{"Gelegen op het noordwesten bereikbaar via achterom", "noordwesten"},
+		{"", ""},
+	}
+
+	for _, tt := range tests {
+		if got := parseGardenOrientation(tt.in); got != tt.exp {
+			t.Errorf("parseGardenOrientation(%q) = %q, expected %q", tt.in, got, tt.exp)
+		}
+	}
+}
+
+func TestParseFloor(t *testing.T) {
+	tests := []struct {
+		in  string
+		exp int
+	}{
+		{"Begane grond", 0},
+		{"1e woonlaag", 1},
+		{"12e woonlaag", 12},
+		{"Onbekend", FloorUnknown},
+	}
+
+	for _, tt := range tests {
+		if got := parseFloor(tt.in); got != tt.exp {
+			t.Errorf("parseFloor(%q) = %v, expected %v", tt.in, got, tt.exp)
+		}
+	}
+}
+
+func TestSplitList(t *testing.T) {
+	tests := []struct {
+		in  string
+		exp []string
+	}{
+		{"Cv-ketel, Stadsverwarming", []string{"Cv-ketel", "Stadsverwarming"}},
+		{"Dakisolatie, muurisolatie en dubbel glas", []string{"Dakisolatie", "muurisolatie", "dubbel glas"}},
+		{"C.V.-ketel", []string{"C.V.-ketel"}},
+		{"", nil},
+	}
+
+	for _, tt := range tests {
+		if got := splitList(tt.in); !reflect.DeepEqual(got, tt.exp) {
+			t.Errorf("splitList(%q) = %q, expected %q", tt.in, got, tt.exp)
+		}
+	}
+}
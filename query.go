@@ -0,0 +1,83 @@
+package funda
+
+import (
+	"errors"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// SearchQuery defines search filters, to be encoded into the search options
+// path segment the Funda API expects, e.g. "/amsterdam/0-400000/".
+type SearchQuery struct {
+	// Municipalities to search in, e.g. "amsterdam" or "den haag".
+	Municipalities []string
+	// MinPrice and MaxPrice define the price range in euros. Zero means
+	// unbounded.
+	MinPrice int
+	MaxPrice int
+	// MinArea is the minimum living area in square meters. Zero means
+	// unbounded.
+	MinArea int
+}
+
+// Validate returns an error if the query contains an invalid combination of
+// filters.
+func (q SearchQuery) Validate() error {
+	if q.MinPrice < 0 || q.MaxPrice < 0 {
+		return errors.New("funda: price must not be negative")
+	}
+	if q.MaxPrice > 0 && q.MinPrice > q.MaxPrice {
+		return errors.New("funda: minimum price exceeds maximum price")
+	}
+	if q.MinArea < 0 {
+		return errors.New("funda: area must not be negative")
+	}
+
+	return nil
+}
+
+// String encodes the query into search options, e.g. "/amsterdam/0-400000/".
+func (q SearchQuery) String() string {
+	var segments []string
+
+	if len(q.Municipalities) > 0 {
+		munis := make([]string, len(q.Municipalities))
+		for i, m := range q.Municipalities {
+			m = strings.ToLower(strings.TrimSpace(m))
+			munis[i] = url.PathEscape(strings.ReplaceAll(m, " ", "-"))
+		}
+		segments = append(segments, strings.Join(munis, ","))
+	}
+
+	switch {
+	case q.MaxPrice > 0:
+		segments = append(segments, strconv.Itoa(q.MinPrice)+"-"+strconv.Itoa(q.MaxPrice))
+	case q.MinPrice > 0:
+		segments = append(segments, strconv.Itoa(q.MinPrice)+"+")
+	}
+
+	if q.MinArea > 0 {
+		segments = append(segments, strconv.Itoa(q.MinArea)+"+woonopp")
+	}
+
+	if len(segments) == 0 {
+		return ""
+	}
+
+	return "/" + strings.Join(segments, "/") + "/"
+}
+
+// WebURL returns the search on the funda.nl website equivalent to the query,
+// e.g. "https://www.funda.nl/koop/amsterdam/0-400000/".
+func (q SearchQuery) WebURL() url.URL {
+	path := "/koop" + q.String()
+	if path == "/koop" {
+		path += "/"
+	}
+
+	u := url.URL{Scheme: "https", Host: "www.funda.nl", RawPath: path}
+	u.Path, _ = url.PathUnescape(path)
+
+	return u
+}
@@ -0,0 +1,48 @@
+package funda
+
+import "testing"
+
+func TestSearchQueryString(t *testing.T) {
+	tests := []struct {
+		q   SearchQuery
+		exp string
+	}{
+		{SearchQuery{}, ""},
+		{SearchQuery{Municipalities: []string{"Amsterdam"}, MaxPrice: 400000}, "/amsterdam/0-400000/"},
+		{SearchQuery{Municipalities: []string{"amsterdam", "Den Haag"}}, "/amsterdam,den-haag/"},
+		{SearchQuery{MinPrice: 200000, MinArea: 50}, "/200000+/50+woonopp/"},
+	}
+
+	for _, tt := range tests {
+		if got := tt.q.String(); got != tt.exp {
+			t.Errorf("Got: %q, expected %q", got, tt.exp)
+		}
+	}
+}
+
+func TestSearchQueryValidate(t *testing.T) {
+	if err := (SearchQuery{MinPrice: 500000, MaxPrice: 400000}).Validate(); err == nil {
+		t.Errorf("Got: %v, expected an error", err)
+	}
+
+	if err := (SearchQuery{MinPrice: 400000}).Validate(); err != nil {
+		t.Errorf("Got: %v, expected %v", err, nil)
+	}
+}
+
+func TestSearchQueryWebURL(t *testing.T) {
+	tests := []struct {
+		q   SearchQuery
+		exp string
+	}{
+		{SearchQuery{}, "https://www.funda.nl/koop/"},
+		{SearchQuery{Municipalities: []string{"Amsterdam", "Den Haag"}, MinPrice: 200000, MaxPrice: 400000}, "https://www.funda.nl/koop/amsterdam,den-haag/200000-400000/"},
+	}
+
+	for _, tt := range tests {
+		u := tt.q.WebURL()
+		if got := u.String(); got != tt.exp {
+			t.Errorf("Got: %q, expected %q", got, tt.exp)
+		}
+	}
+}
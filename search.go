@@ -0,0 +1,180 @@
+package funda
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/url"
+	"strconv"
+)
+
+// SearchResult is a page of houses returned by a search, with paging
+// metadata.
+type SearchResult struct {
+	Houses []*House
+	// TotalCount is the total number of search results across all pages. It
+	// is zero when the Funda API doesn't report it.
+	TotalCount  int
+	Page        int
+	PageSize    int
+	HasNextPage bool
+	// Facets holds the number of search results per filter option, e.g. per
+	// price range, by filter name. It is empty when the Funda API doesn't
+	// report them.
+	Facets map[string][]Facet
+
+	// listings is the number of listings in the response, including those
+	// omitted from Houses, e.g. with ExcludeSold.
+	listings int
+}
+
+// Facet is a filter option with the number of search results matching it,
+// e.g. "Amsterdam" with 1234 results.
+type Facet struct {
+	Label string
+	Count int
+}
+
+// SearchPage does a house search request at the Funda API, like Search, and
+// returns the houses together with paging metadata.
+func (c *Client) SearchPage(searchOpts string, page, pageSize int) (*SearchResult, error) {
+	return c.SearchPageContext(context.Background(), searchOpts, page, pageSize)
+}
+
+// SearchPageContext is like SearchPage, with a context used for the search
+// request and every house detail request it spawns.
+func (c *Client) SearchPageContext(ctx context.Context, searchOpts string, page, pageSize int) (*SearchResult, error) {
+	return c.searchPage(ctx, ListingBuy, searchOpts, page, pageSize)
+}
+
+// SearchURL does a house search request at rawURL, a fully formed search URL
+// of the Funda API, e.g. as captured from the app. Listings are assumed to be
+// for sale. The page and page size are taken from the "page" and "pageSize"
+// query parameters, when present.
+func (c *Client) SearchURL(rawURL string) (*SearchResult, error) {
+	return c.SearchURLContext(context.Background(), rawURL)
+}
+
+// SearchURLContext is like SearchURL, with a context used for the search
+// request and every house detail request it spawns.
+func (c *Client) SearchURLContext(ctx context.Context, rawURL string) (*SearchResult, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return nil, fmt.Errorf("funda: could not parse search URL: %w", err)
+	}
+
+	q := u.Query()
+	page, _ := strconv.Atoi(q.Get("page"))
+	pageSize, _ := strconv.Atoi(q.Get("pageSize"))
+
+	return c.searchURL(ctx, ListingBuy, u, page, pageSize)
+}
+
+// ParseSearchResult parses a search response body, e.g. as saved from an
+// earlier request, without requesting house details. Like a search with the
+// default Client, ads and incomplete results are skipped.
+func ParseSearchResult(r io.Reader) (*SearchResult, error) {
+	body, err := decodeSearchResponse(r)
+	if err != nil {
+		return nil, fmt.Errorf("funda: could not parse search result: %w", err)
+	}
+
+	var c Client
+	houses, err := c.parseSearchResult(ListingBuy, body.Objects)
+	if err != nil {
+		return nil, fmt.Errorf("funda: could not parse houses from search result: %w", err)
+	}
+
+	return body.searchResult(houses, 0, 0), nil
+}
+
+// searchResponse is the body of a search response. The Funda API either
+// returns a bare list of result items, or an envelope with the items and
+// paging metadata.
+type searchResponse struct {
+	Objects              searchResult `json:"Objects"`
+	TotaalAantalObjecten int          `json:"TotaalAantalObjecten"`
+	Paging               struct {
+		AantalPaginas int    `json:"AantalPaginas"`
+		HuidigePagina int    `json:"HuidigePagina"`
+		VolgendeURL   string `json:"VolgendeUrl"`
+	} `json:"Paging"`
+	Facetten []struct {
+		Naam   string `json:"Naam"`
+		Opties []struct {
+			Label  string `json:"Label"`
+			Aantal int    `json:"Aantal"`
+		} `json:"Opties"`
+	} `json:"Facetten"`
+
+	// hasPaging is set when the response was an envelope.
+	hasPaging bool
+}
+
+func decodeSearchResponse(r io.Reader) (searchResponse, error) {
+	var resp searchResponse
+
+	var raw json.RawMessage
+	if err := json.NewDecoder(r).Decode(&raw); err != nil {
+		return resp, err
+	}
+
+	if raw[0] == '[' {
+		err := json.Unmarshal(raw, &resp.Objects)
+		return resp, err
+	}
+
+	if err := json.Unmarshal(raw, &resp); err != nil {
+		return resp, err
+	}
+	resp.hasPaging = true
+
+	return resp, nil
+}
+
+// searchResult returns a SearchResult for the houses parsed from the
+// response. Without paging metadata, a next page is assumed to exist when the
+// page was filled up with listings.
+func (resp searchResponse) searchResult(houses []*House, page, pageSize int) *SearchResult {
+	result := &SearchResult{
+		Houses:     houses,
+		TotalCount: resp.TotaalAantalObjecten,
+		listings:   resp.Objects.listingCount(),
+		Page:       page,
+		PageSize:   pageSize,
+	}
+
+	for _, f := range resp.Facetten {
+		if result.Facets == nil {
+			result.Facets = make(map[string][]Facet)
+		}
+		for _, o := range f.Opties {
+			result.Facets[f.Naam] = append(result.Facets[f.Naam], Facet{Label: o.Label, Count: o.Aantal})
+		}
+	}
+
+	if resp.hasPaging {
+		if resp.Paging.HuidigePagina > 0 {
+			result.Page = resp.Paging.HuidigePagina
+		}
+		result.HasNextPage = resp.Paging.VolgendeURL != "" ||
+			resp.Paging.HuidigePagina < resp.Paging.AantalPaginas
+		return result
+	}
+
+	result.HasNextPage = pageSize > 0 && resp.Objects.listingCount() >= pageSize
+
+	return result
+}
+
+// listingCount returns the number of items that are listings, i.e. not ads.
+func (r searchResult) listingCount() int {
+	n := 0
+	for _, item := range r {
+		if item.ItemType == 1 {
+			n++
+		}
+	}
+	return n
+}